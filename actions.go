@@ -3,12 +3,21 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"slices"
 )
 
 // New returns an error with the supplied message and a stack trace to the point
-// where the function was called.
-func New(message string) error {
-	return newError(message, 3)
+// where the function was called. Options such as [SkipFrames] and [CallerOf]
+// change where the stack trace starts.
+func New(message string, opts ...StackOption) error {
+	o := options{}
+	for _, opt := range opts {
+		opt.applyStack(&o)
+	}
+
+	err := newError(message, o)
+	recordCreated(err, "")
+	return err
 }
 
 // As finds the first error in err's tree that matches target, and if one is
@@ -109,12 +118,19 @@ func UnwrapAny(err error) []error {
 	return make([]error, 0)
 }
 
-// StackTrace returns a [Stack], if err has one. If none was found, the returned
-// bool will be false.
+// StackTrace returns a [Stack], if err has one. If none was found, or the
+// error was created without capturing one according to the [CapturePolicy],
+// the returned bool will be false.
+//
+// In addition to errors that implement [StackTracer], stack traces are found on
+// errors that provide a `Callers() []uintptr` method, like those from
+// github.com/go-errors/errors, and on errors of types registered with
+// [AdaptStackTrace], like those from github.com/pkg/errors.
+//
+// The returned [Stack] is a copy, so changing it does not affect other errors.
 func StackTrace(err error) (Stack, bool) {
-	var s StackTracer
-	if As(err, &s) {
-		return s.StackTrace(), true
+	if st, ok := findStack(err); ok && !st.IsZero() {
+		return slices.Clone(st), true
 	}
 
 	return make(Stack, 0), false
@@ -124,36 +140,76 @@ type options struct {
 	overwrite  bool
 	underlying error
 	skip       int
+	frames     int
+	callerOf   string
+	category   string
 }
 
-// StackOption is an option for the WithStack function.
-type StackOption func(*options)
+// StackOption configures the stack trace captured by [New], [Errorf] and
+// [WithStack]. A [CaptureOption], such as [SkipFrames], is also a
+// [UserFacingOption].
+//
+// StackOption and [UserFacingOption] were function types in earlier versions.
+// They are interfaces now, so that a [CaptureOption] can satisfy both while
+// options such as [FromError] remain specific to one. Code that converted
+// functions to either type, or compared options to nil, needs to be updated.
+type StackOption interface {
+	applyStack(*options)
+}
+
+type stackOption func(*options)
+
+func (f stackOption) applyStack(o *options) { f(o) }
+
+// CaptureOption changes where the stack trace captured for a new error starts.
+// It is accepted by [New], [Errorf], [WithStack], [NewError] and
+// [NewUserFacingError]. Since [StackOption] and [UserFacingOption] are
+// interfaces, a slice of capture options has to be converted element by
+// element to pass it on:
+//
+//	stackOpts := make([]errors.StackOption, len(opts))
+//	for i, opt := range opts {
+//		stackOpts[i] = opt
+//	}
+type CaptureOption func(*options)
+
+func (f CaptureOption) applyStack(o *options) { f(o) }
+
+func (f CaptureOption) applyUserFacing(o *options) { f(o) }
 
 // Overwrite is an option that sets the stack trace to the code location where
 // [WithStack] was called, even if the error already had a stack trace.
 func Overwrite() StackOption {
-	return func(o *options) {
+	return stackOption(func(o *options) {
 		o.overwrite = true
-	}
+	})
 }
 
 // WithStack adds a [Stack] to the provided error at the point where the
 // function was called. If the error already has a [Stack], it will be
-// retained unless the [Overwrite] option is provided.
+// retained unless the [Overwrite] option is provided. A stack trace from
+// another package that is recognized by [StackTrace] is also retained, and
+// exposed by the returned error's StackTrace method.
 func WithStack(err error, opts ...StackOption) error {
 	o := options{}
 	for _, opt := range opts {
-		opt(&o)
+		opt.applyStack(&o)
 	}
 
 	if !o.overwrite {
 		var s StackTracer
-		if As(err, &s) {
+		if As(err, &s) && !s.StackTrace().IsZero() {
 			return err
 		}
+
+		// Errors created without a stack trace according to the CapturePolicy
+		// are given one like any other error.
+		if st, ok := findStack(err); ok && !st.IsZero() {
+			return Error{message: err.Error(), template: err.Error(), err: err, stack: st}
+		}
 	}
 
-	return wrapError(err, 3)
+	return wrapError(err, o)
 }
 
 // Errorf formats according to a format specifier and returns the string as a
@@ -169,7 +225,8 @@ func WithStack(err error, opts ...StackOption) error {
 // synonym for %v.
 //
 // If used to wrap errors, the [Overwrite] option can be provided as the final
-// argument to overwrite any stack traces on the wrapped errors.
+// argument to overwrite any stack traces on the wrapped errors. Other
+// [StackOption]s, such as [SkipFrames], can be provided in the same way.
 func Errorf(format string, args ...any) error {
 	options := options{}
 
@@ -177,7 +234,7 @@ func Errorf(format string, args ...any) error {
 
 	for i := len(args) - 1; i >= 0; i-- {
 		if opt, ok := args[i].(StackOption); ok {
-			opt(&options)
+			opt.applyStack(&options)
 		} else {
 			operands = args[:i+1]
 			break
@@ -187,11 +244,18 @@ func Errorf(format string, args ...any) error {
 	e := fmt.Errorf(format, operands...)
 
 	if !options.overwrite {
-		var s StackTracer
-		if As(e, &s) {
-			return Error{message: e.Error(), err: e, stack: s.StackTrace()}
+		if st, ok := findStack(e); ok && !st.IsZero() {
+			return Error{message: e.Error(), template: format, err: e, stack: st}
 		}
 	}
 
-	return wrapError(e, 3)
+	err := wrapError(e, options)
+	err.template = format
+
+	// Wrapping an existing error does not create a new one.
+	if len(UnwrapAny(e)) == 0 {
+		recordCreated(err, "")
+	}
+
+	return err
 }
@@ -0,0 +1,96 @@
+package errors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// callersTracer is implemented by errors from github.com/go-errors/errors.
+type callersTracer interface {
+	Callers() []uintptr
+}
+
+type adapter func(error) (Stack, bool)
+
+var (
+	adaptersMu sync.Mutex
+	adapters   atomic.Pointer[[]adapter]
+)
+
+// AdaptStackTrace registers a type of stack trace provided by errors from
+// another package, so that [StackTrace], [WithStack] and [Errorf] recognize
+// errors with a `StackTrace() S` method. The elements of S must be program
+// counters, as returned by runtime.Callers.
+//
+// For example, errors from github.com/pkg/errors can be supported with:
+//
+//	errors.AdaptStackTrace[pkgerrors.StackTrace]()
+func AdaptStackTrace[S ~[]F, F ~uintptr]() {
+	type tracer interface {
+		StackTrace() S
+	}
+
+	adapt := func(err error) (Stack, bool) {
+		t, ok := err.(tracer)
+		if !ok {
+			return nil, false
+		}
+
+		trace := t.StackTrace()
+		pcs := make([]uintptr, len(trace))
+		for i, pc := range trace {
+			pcs[i] = uintptr(pc)
+		}
+
+		return stackFromPCs(pcs), true
+	}
+
+	adaptersMu.Lock()
+	defer adaptersMu.Unlock()
+
+	var registered []adapter
+	if current := adapters.Load(); current != nil {
+		registered = append(registered, *current...)
+	}
+	registered = append(registered, adapt)
+	adapters.Store(&registered)
+}
+
+// findStack returns the first stack trace found in a depth-first traversal of
+// err's tree.
+func findStack(err error) (Stack, bool) {
+	if st, ok := ownStack(err); ok {
+		return st, true
+	}
+
+	for _, child := range children(err) {
+		if st, ok := findStack(child); ok {
+			return st, true
+		}
+	}
+
+	return nil, false
+}
+
+// ownStack returns the stack trace provided by err itself, without
+// considering the errors it wraps.
+func ownStack(err error) (Stack, bool) {
+	switch e := err.(type) {
+	case Error:
+		return e.stack, true
+	case StackTracer:
+		return e.StackTrace(), true
+	case callersTracer:
+		return stackFromPCs(e.Callers()), true
+	}
+
+	if registered := adapters.Load(); registered != nil {
+		for _, adapt := range *registered {
+			if st, ok := adapt(err); ok {
+				return st, true
+			}
+		}
+	}
+
+	return nil, false
+}
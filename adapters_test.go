@@ -0,0 +1,137 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pkgFrame and pkgStackTrace mimic the types from github.com/pkg/errors.
+type (
+	pkgFrame      uintptr
+	pkgStackTrace []pkgFrame
+)
+
+type pkgError struct {
+	msg   string
+	stack []uintptr
+}
+
+func newPkgError(msg string) error {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	return &pkgError{msg: msg, stack: pcs[:n]}
+}
+
+func (e *pkgError) Error() string {
+	return e.msg
+}
+
+func (e *pkgError) StackTrace() pkgStackTrace {
+	trace := make(pkgStackTrace, len(e.stack))
+	for i, pc := range e.stack {
+		trace[i] = pkgFrame(pc)
+	}
+
+	return trace
+}
+
+// goError mimics the error type from github.com/go-errors/errors.
+type goError struct {
+	msg   string
+	stack []uintptr
+}
+
+func newGoError(msg string) error {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	return &goError{msg: msg, stack: pcs[:n]}
+}
+
+func (e *goError) Error() string {
+	return e.msg
+}
+
+func (e *goError) Callers() []uintptr {
+	return e.stack
+}
+
+type unregisteredError struct{}
+
+func (unregisteredError) Error() string {
+	return "unregistered"
+}
+
+func (unregisteredError) StackTrace() []uintptr {
+	pcs := make([]uintptr, 32)
+	return pcs[:runtime.Callers(1, pcs)]
+}
+
+func init() {
+	errors.AdaptStackTrace[pkgStackTrace]()
+}
+
+func TestAdaptStackTrace(t *testing.T) {
+	tests := map[string]func(string) error{
+		"pkg/errors": newPkgError,
+		"go-errors":  newGoError,
+	}
+
+	for name, create := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Run("StackTrace", func(t *testing.T) {
+				line := nextLine()
+				err := fmt.Errorf("wrapped: %w", create("foreign"))
+
+				stack, ok := errors.StackTrace(err)
+				require.True(t, ok, "should recognize the stack trace")
+				assert.Equal(t, fmt.Sprintf("adapters_test.go:%d", line), fmt.Sprintf("%s", stack[0]))
+			})
+
+			t.Run("WithStack", func(t *testing.T) {
+				line := nextLine()
+				original := create("foreign")
+				err := errors.WithStack(original)
+
+				found := fmt.Sprintf("%+s", err)
+				expect := fmt.Sprintf("foreign: [adapters_test.go:%d", line)
+				assert.Contains(t, found, expect, "should retain the foreign stack trace")
+				assert.ErrorIs(t, err, original, "should wrap the original error")
+
+				var tracer errors.StackTracer
+				assert.True(t, errors.As(err, &tracer), "should implement StackTracer")
+			})
+
+			t.Run("WithStack overwrite", func(t *testing.T) {
+				original := create("foreign")
+				line := nextLine()
+				err := errors.WithStack(original, errors.Overwrite())
+
+				found := fmt.Sprintf("%+s", err)
+				expect := fmt.Sprintf("foreign: [adapters_test.go:%d", line)
+				assert.Contains(t, found, expect, "should overwrite the foreign stack trace")
+			})
+
+			t.Run("Errorf", func(t *testing.T) {
+				line := nextLine()
+				original := create("foreign")
+				err := errors.Errorf("wrapped: %w", original)
+
+				found := fmt.Sprintf("%+s", err)
+				expect := fmt.Sprintf("wrapped: foreign: [adapters_test.go:%d", line)
+				assert.Contains(t, found, expect, "should retain the foreign stack trace")
+			})
+		})
+	}
+
+	t.Run("unregistered", func(t *testing.T) {
+		_, ok := errors.StackTrace(unregisteredError{})
+		assert.False(t, ok, "should not recognize unregistered stack traces")
+	})
+}
@@ -0,0 +1,31 @@
+//go:build errors_nocapture
+
+package errors_test
+
+import (
+	std "errors"
+	"fmt"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapturePolicyDisabled(t *testing.T) {
+	errors.SetCapturePolicy(errors.CaptureAlways())
+	defer errors.SetCapturePolicy(nil)
+
+	for _, err := range []error{
+		errors.New("oops"),
+		errors.Errorf("oops: %d", 1),
+		errors.WithStack(fmt.Errorf("oops")),
+		errors.NewError[errors.BadInputError]("bad input"),
+	} {
+		st, ok := errors.StackTrace(err)
+		assert.False(t, ok, "should not capture stack traces")
+		assert.True(t, st.IsZero())
+	}
+
+	_, ok := errors.SpawnedFrom(<-errors.Go(func() error { return std.New("oops") }))
+	assert.False(t, ok, "should not capture spawn sites")
+}
@@ -0,0 +1,7 @@
+//go:build !errors_nocapture
+
+package errors
+
+// captureEnabled reports whether new errors may capture stack traces. It is
+// disabled by the errors_nocapture build tag.
+const captureEnabled = true
@@ -0,0 +1,51 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapturePolicyEnabled(t *testing.T) {
+	t.Run("per category", func(t *testing.T) {
+		errors.SetCapturePolicy(errors.CaptureExcept("BadInputError"))
+		defer errors.SetCapturePolicy(nil)
+
+		_, ok := errors.StackTrace(errors.NewError[errors.BadInputError]("bad input"))
+		assert.False(t, ok)
+
+		_, ok = errors.StackTrace(errors.NewError[errors.TimeoutError]("timed out"))
+		assert.True(t, ok)
+
+		_, ok = errors.StackTrace(errors.New("oops"))
+		assert.True(t, ok)
+	})
+
+	t.Run("restored", func(t *testing.T) {
+		errors.SetCapturePolicy(errors.CaptureNever())
+		errors.SetCapturePolicy(nil)
+
+		_, ok := errors.StackTrace(errors.New("oops"))
+		assert.True(t, ok)
+	})
+
+	t.Run("wrapping uncaptured errors", func(t *testing.T) {
+		errors.SetCapturePolicy(errors.CaptureExcept("BadInputError"))
+		defer errors.SetCapturePolicy(nil)
+
+		badInput := errors.NewError[errors.BadInputError]("bad input")
+
+		_, ok := errors.StackTrace(errors.Errorf("ctx: %w", badInput))
+		assert.True(t, ok, "Errorf should capture a stack trace")
+
+		_, ok = errors.StackTrace(errors.WithStack(badInput))
+		assert.True(t, ok, "WithStack should capture a stack trace")
+
+		_, ok = errors.StackTrace(errors.WithStack(fmt.Errorf("ctx: %w", badInput)))
+		assert.True(t, ok, "WithStack should capture a stack trace for wrapped errors")
+	})
+}
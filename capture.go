@@ -0,0 +1,89 @@
+package errors
+
+import (
+	"sync/atomic"
+)
+
+// CapturePolicy decides whether a stack trace is captured for a new error. It
+// is given the [Category] of the error being created, which is empty for
+// errors created by [New], [Errorf] and [WithStack]. Policies must be safe for
+// concurrent use. See [SetCapturePolicy].
+type CapturePolicy func(category string) bool
+
+// CaptureAlways is a [CapturePolicy] that captures a stack trace for every
+// error, which is the default.
+func CaptureAlways() CapturePolicy {
+	return func(string) bool {
+		return true
+	}
+}
+
+// CaptureNever is a [CapturePolicy] that never captures stack traces.
+func CaptureNever() CapturePolicy {
+	return func(string) bool {
+		return false
+	}
+}
+
+// CaptureSampled is a [CapturePolicy] that captures a stack trace for one in
+// every n errors.
+func CaptureSampled(n int) CapturePolicy {
+	if n <= 1 {
+		return CaptureAlways()
+	}
+
+	var count atomic.Uint64
+	return func(string) bool {
+		return (count.Add(1)-1)%uint64(n) == 0
+	}
+}
+
+// CaptureExcept is a [CapturePolicy] that captures a stack trace for every
+// error, except for those in the provided categories, such as
+// "BadInputError".
+func CaptureExcept(categories ...string) CapturePolicy {
+	skip := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		skip[category] = true
+	}
+
+	return func(category string) bool {
+		return !skip[category]
+	}
+}
+
+var capturePolicy atomic.Pointer[CapturePolicy]
+
+// SetCapturePolicy sets the [CapturePolicy] that decides whether new errors
+// capture a stack trace. Errors created without one have a [Stack] for which
+// IsZero reports true, and [StackTrace] reports false for them. The places
+// that start goroutines with [Go], [GoContext] and [Group.Go] are only
+// recorded when the policy captures errors without a category. Providing nil
+// restores [CaptureAlways], which is the default.
+//
+// Capturing stack traces can be compiled out entirely with the
+// errors_nocapture build tag, in which case the policy is never consulted.
+//
+//	errors.SetCapturePolicy(errors.CaptureExcept("BadInputError", "MissingError"))
+func SetCapturePolicy(p CapturePolicy) {
+	if p == nil {
+		capturePolicy.Store(nil)
+		return
+	}
+
+	capturePolicy.Store(&p)
+}
+
+// shouldCapture reports whether a stack trace should be captured for a new
+// error in the given category.
+func shouldCapture(category string) bool {
+	if !captureEnabled {
+		return false
+	}
+
+	if p := capturePolicy.Load(); p != nil {
+		return (*p)(category)
+	}
+
+	return true
+}
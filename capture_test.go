@@ -0,0 +1,61 @@
+package errors_test
+
+import (
+	"context"
+	std "errors"
+	"fmt"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapturePolicy(t *testing.T) {
+	t.Run("policies", func(t *testing.T) {
+		assert.True(t, errors.CaptureAlways()(""))
+		assert.False(t, errors.CaptureNever()(""))
+
+		except := errors.CaptureExcept("BadInputError", "MissingError")
+		assert.True(t, except(""))
+		assert.True(t, except("TimeoutError"))
+		assert.False(t, except("BadInputError"))
+		assert.False(t, except("MissingError"))
+
+		sampled := errors.CaptureSampled(3)
+		found := []bool{}
+		for i := 0; i < 6; i++ {
+			found = append(found, sampled(""))
+		}
+		assert.Equal(t, []bool{true, false, false, true, false, false}, found)
+		assert.True(t, errors.CaptureSampled(0)(""))
+	})
+
+	t.Run("never", func(t *testing.T) {
+		errors.SetCapturePolicy(errors.CaptureNever())
+		defer errors.SetCapturePolicy(nil)
+
+		for _, err := range []error{
+			errors.New("oops"),
+			errors.Errorf("oops: %d", 1),
+			errors.WithStack(fmt.Errorf("oops")),
+			errors.NewError[errors.BadInputError]("bad input"),
+		} {
+			st, ok := errors.StackTrace(err)
+			assert.False(t, ok)
+			assert.True(t, st.IsZero())
+			assert.Equal(t, err.Error(), fmt.Sprintf("%+v", err))
+		}
+
+		g := errors.Group{}
+		g.Go(func() error { return std.New("oops") })
+
+		for _, err := range []error{
+			<-errors.Go(func() error { return std.New("oops") }),
+			<-errors.GoContext(context.Background(), func(context.Context) error { return std.New("oops") }),
+			g.Wait(),
+		} {
+			_, ok := errors.SpawnedFrom(err)
+			assert.False(t, ok, "should not capture spawn sites")
+		}
+	})
+}
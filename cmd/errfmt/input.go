@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rclark/errors"
+)
+
+// entry is either a line of the logs to copy unchanged, or an error found in
+// them.
+type entry struct {
+	line string
+
+	header  string
+	message string
+	fields  []string
+	stack   errors.Stack
+	origin  errors.Stack
+}
+
+// scanner splits logs into entries as they are read. It reads at most four
+// lines ahead of the current one, which is enough to recognize the frames of a
+// stack trace, so that each entry is available as soon as its lines are.
+type scanner struct {
+	lines *bufio.Scanner
+	ahead []string
+}
+
+func newScanner(r io.Reader) *scanner {
+	lines := bufio.NewScanner(r)
+	lines.Buffer(nil, maxLineSize)
+	return &scanner{lines: lines}
+}
+
+// maxLineSize is the length of the longest line that can be read, which leaves
+// room for JSON log lines that carry large stack traces.
+const maxLineSize = 16 << 20
+
+// next returns the next entry, or false once the logs have been read.
+func (s *scanner) next() (entry, bool) {
+	if !s.fill(1) {
+		return entry{}, false
+	}
+
+	line := s.ahead[0]
+	if e, ok := scanJSON(line); ok {
+		s.ahead = s.ahead[1:]
+		return e, true
+	}
+
+	var e entry
+	switch {
+	case s.isFrameAt(0):
+	case s.isFrameAt(1), s.isCreationAt(1) && s.isFrameAt(2):
+		e.message = line
+		s.ahead = s.ahead[1:]
+	default:
+		s.ahead = s.ahead[1:]
+		return entry{line: line}, true
+	}
+
+	var block []string
+	if s.isCreationAt(0) {
+		block = append(block, s.ahead[0])
+		s.ahead = s.ahead[1:]
+	}
+
+	for {
+		if s.isFrameAt(0) {
+			block = append(block, s.ahead[:2]...)
+			s.ahead = s.ahead[2:]
+			continue
+		}
+
+		if s.fill(1) && s.ahead[0] == "spawned from:" && s.isFrameAt(1) {
+			block = append(block, s.ahead[0])
+			s.ahead = s.ahead[1:]
+			continue
+		}
+
+		break
+	}
+
+	parsed, _ := errors.ParseError(strings.Join(block, "\n"))
+	e.stack = parsed.StackTrace()
+	e.origin, _ = errors.SpawnedFrom(parsed)
+	e.fields = creationFields(parsed)
+
+	return e, true
+}
+
+// err returns the error, if any, that stopped the logs from being read.
+func (s *scanner) err() error {
+	return s.lines.Err()
+}
+
+// fill reads lines until at least n are buffered, and reports whether there
+// were enough lines left to do so.
+func (s *scanner) fill(n int) bool {
+	for len(s.ahead) < n && s.lines.Scan() {
+		s.ahead = append(s.ahead, strings.TrimSuffix(s.lines.Text(), "\r"))
+	}
+
+	return len(s.ahead) >= n
+}
+
+// isCreationAt reports whether the buffered line at i describes when and in
+// which goroutine an error was created, as in
+// "created at 2024-06-01T12:00:00Z in goroutine 7".
+func (s *scanner) isCreationAt(i int) bool {
+	if !s.fill(i + 1) {
+		return false
+	}
+
+	line := s.ahead[i]
+	return strings.HasPrefix(line, "created at ") && strings.Contains(line, " in goroutine ")
+}
+
+// isFrameAt reports whether the buffered lines at i and i+1 describe a stack
+// frame, as a function name followed by a tab-indented file path and line
+// number.
+func (s *scanner) isFrameAt(i int) bool {
+	if !s.fill(i + 2) {
+		return false
+	}
+
+	function, location := s.ahead[i], s.ahead[i+1]
+	if function == "" || strings.HasPrefix(function, "\t") || !strings.HasPrefix(location, "\t") {
+		return false
+	}
+
+	st, err := errors.ParseStack(function + "\n" + location)
+	if err != nil {
+		return false
+	}
+
+	// Insist on Go source files to avoid mistaking other indented lines for
+	// frames.
+	return strings.HasSuffix(st[0].File, ".go") || strings.HasSuffix(st[0].File, ".s")
+}
+
+// scanJSON finds an error in a JSON log line. The error is either serialized
+// as a "stack" array of frames, as in an [errors.Report], or is a string value
+// holding an error formatted with %+v.
+func scanJSON(line string) (entry, bool) {
+	if !strings.HasPrefix(strings.TrimSpace(line), "{") {
+		return entry{}, false
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(line), &obj); err != nil {
+		return entry{}, false
+	}
+
+	e := entry{
+		header:  strings.TrimSpace(stringField(obj, "time") + " " + stringField(obj, "level")),
+		message: stringField(obj, "message", "msg"),
+	}
+
+	for _, key := range []string{"category", "fingerprint", "user_message"} {
+		if v := stringField(obj, key); v != "" {
+			e.fields = append(e.fields, key+"="+v)
+		}
+	}
+
+	if raw, ok := obj["stack"]; ok && json.Unmarshal(raw, &e.stack) == nil && !e.stack.IsZero() {
+		if raw, ok := obj["spawned_from"]; ok {
+			_ = json.Unmarshal(raw, &e.origin)
+		}
+
+		return e, true
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		v := stringField(obj, key)
+		if !strings.Contains(v, "\n\t") {
+			continue
+		}
+
+		parsed, err := errors.ParseError(v)
+		if err != nil {
+			continue
+		}
+
+		e.stack = parsed.StackTrace()
+		e.origin, _ = errors.SpawnedFrom(parsed)
+		e.fields = append(e.fields, creationFields(parsed)...)
+		switch msg := parsed.Error(); {
+		case e.message == "":
+			e.message = msg
+		case msg != "" && msg != e.message:
+			e.message += ": " + msg
+		}
+
+		return e, true
+	}
+
+	return entry{}, false
+}
+
+// creationFields describes when and in which goroutine err was created, if it
+// was recorded.
+func creationFields(err error) []string {
+	at, ok := errors.CreatedAt(err)
+	if !ok {
+		return nil
+	}
+
+	id, _ := errors.GoroutineID(err)
+	return []string{"created_at=" + at.Format(time.RFC3339Nano), "goroutine=" + strconv.FormatUint(id, 10)}
+}
+
+// stringField returns the first of the named fields that holds a string.
+func stringField(obj map[string]json.RawMessage, keys ...string) string {
+	for _, key := range keys {
+		var s string
+		if raw, ok := obj[key]; ok && json.Unmarshal(raw, &s) == nil {
+			return s
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,111 @@
+//go:build !errors_nocapture
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCaptured(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		err := errors.New("it broke")
+		logs := fmt.Sprintf("starting\n%+v\nstopping\n", err)
+
+		found := format(t, config{}, logs)
+		lines := strings.Split(found, "\n")
+
+		assert.Equal(t, "starting", lines[0])
+		assert.Equal(t, "it broke", lines[1])
+		assert.Equal(t, "  github.com/rclark/errors/cmd/errfmt.TestRunCaptured.func1", lines[2])
+		assert.Contains(t, lines[3], "cmd/errfmt/main-stack_test.go:")
+		assert.Contains(t, found, "standard library frame")
+		assert.True(t, strings.HasSuffix(found, "\nstopping\n"))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		report := errors.NewReport(errors.NewError[errors.BadInputError]("bad input"))
+		b, err := json.Marshal(report)
+		require.NoError(t, err)
+
+		found := format(t, config{}, string(b)+"\n")
+		lines := strings.Split(found, "\n")
+
+		assert.Equal(t, report.Time.Format(time.RFC3339Nano)+" bad input", lines[0])
+		assert.Equal(t, "  category=BadInputError fingerprint="+report.Fingerprint+" user_message=bad input", lines[1])
+		assert.Equal(t, "  github.com/rclark/errors/cmd/errfmt.TestRunCaptured.func2", lines[2])
+	})
+
+	t.Run("json with formatted error", func(t *testing.T) {
+		line, err := json.Marshal(map[string]string{
+			"level": "ERROR",
+			"msg":   "request failed",
+			"error": fmt.Sprintf("%+v", errors.New("it broke")),
+		})
+		require.NoError(t, err)
+
+		found := format(t, config{}, string(line))
+		assert.True(t, strings.HasPrefix(found, "ERROR request failed: it broke\n  github.com/rclark/errors/cmd/errfmt.TestRunCaptured.func3\n"), found)
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		logs, write := io.Pipe()
+		rendered, out := io.Pipe()
+
+		done := make(chan error, 1)
+		go func() {
+			done <- run(logs, out, config{})
+			out.Close()
+		}()
+
+		go fmt.Fprintf(write, "%+v\nstill\nrunning\n", errors.New("it broke"))
+
+		lines := make(chan string)
+		go func() {
+			defer close(lines)
+			s := bufio.NewScanner(rendered)
+			for s.Scan() {
+				lines <- s.Text()
+			}
+		}()
+
+		for _, want := range []string{"it broke", "  github.com/rclark/errors/cmd/errfmt.TestRunCaptured.func4"} {
+			select {
+			case line := <-lines:
+				assert.Equal(t, want, line)
+			case <-time.After(5 * time.Second):
+				t.Fatal("should render errors before the logs end")
+			}
+		}
+
+		write.Close()
+		for range lines {
+		}
+		require.NoError(t, <-done)
+	})
+
+	t.Run("creation", func(t *testing.T) {
+		errors.SetRecordCreation(true)
+		err := errors.New("it broke")
+		errors.SetRecordCreation(false)
+
+		found := format(t, config{}, fmt.Sprintf("starting\n%+v\n", err))
+		lines := strings.Split(found, "\n")
+
+		at, _ := errors.CreatedAt(err)
+		id, _ := errors.GoroutineID(err)
+		assert.Equal(t, "starting", lines[0])
+		assert.Equal(t, "it broke", lines[1])
+		assert.Equal(t, fmt.Sprintf("  created_at=%s goroutine=%d", at.Format(time.RFC3339Nano), id), lines[2])
+		assert.True(t, strings.HasPrefix(lines[3], "  github.com/rclark/errors/cmd/errfmt.TestRunCaptured."), lines[3])
+	})
+}
@@ -0,0 +1,116 @@
+// Command errfmt pretty-prints the errors and stack traces found in logs.
+//
+// It reads logs from stdin, looking for errors that were formatted with %+v,
+// goroutine stack traces printed by the Go runtime, and JSON log lines that
+// carry a "stack" array like the one serialized by errors.Report. Each one is
+// rendered with colors, trimmed file paths and collapsed standard library
+// frames. All other lines are copied to stdout unchanged.
+//
+// Usage:
+//
+//	errfmt [flags] < app.log
+//
+// The flags are:
+//
+//	-color string
+//		When to use colors: auto, always or never. (default "auto")
+//	-context int
+//		Lines of source code to show around each in-app frame. (default 2)
+//	-module string
+//		Import path of the module that produced the logs, used to trim its
+//		file paths and to determine which frames are in-app.
+//	-src string
+//		Directory holding a checkout of the module, used to show source code
+//		snippets for in-app frames.
+//	-stdlib
+//		Show standard library frames rather than collapsing them.
+//
+// Colors are disabled in auto mode when stdout is not a terminal or when the
+// NO_COLOR environment variable is set.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	var (
+		cfg   config
+		color string
+	)
+
+	flag.StringVar(&color, "color", "auto", "When to use colors: auto, always or never.")
+	flag.IntVar(&cfg.context, "context", 2, "Lines of source code to show around each in-app frame.")
+	flag.StringVar(&cfg.module, "module", "", "Import path of the module that produced the logs.")
+	flag.StringVar(&cfg.src, "src", "", "Directory holding a checkout of the module, used to show source code snippets.")
+	flag.BoolVar(&cfg.stdlib, "stdlib", false, "Show standard library frames rather than collapsing them.")
+	flag.Parse()
+
+	switch color {
+	case "always":
+		cfg.color = true
+	case "never":
+		cfg.color = false
+	case "auto":
+		cfg.color = isTerminal(os.Stdout) && os.Getenv("NO_COLOR") == ""
+	default:
+		fmt.Fprintf(os.Stderr, "errfmt: invalid -color value %q\n", color)
+		os.Exit(2)
+	}
+
+	if err := run(os.Stdin, os.Stdout, cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "errfmt: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+type config struct {
+	color   bool
+	context int
+	module  string
+	src     string
+	stdlib  bool
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// run copies the logs from r to w, rendering the errors and stack traces that
+// it finds along the way. Each entry is written as soon as it has been read, so
+// that logs that are still being written are shown as they arrive.
+func run(r io.Reader, w io.Writer, cfg config) error {
+	out := bufio.NewWriter(w)
+
+	p := printer{cfg: cfg, w: out, files: map[string][]string{}}
+	p.setup()
+
+	s := newScanner(r)
+	for {
+		e, ok := s.next()
+		if !ok {
+			break
+		}
+
+		if e.stack.IsZero() && e.origin.IsZero() {
+			p.printf("%s\n", e.line)
+		} else {
+			p.print(e)
+		}
+
+		if err := out.Flush(); p.err == nil {
+			p.err = err
+		}
+
+		if p.err != nil {
+			return p.err
+		}
+	}
+
+	return s.err()
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors/internal/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func format(t *testing.T, cfg config, logs string) string {
+	t.Helper()
+
+	var out strings.Builder
+	require.NoError(t, run(strings.NewReader(logs), &out, cfg))
+	return out.String()
+}
+
+func TestRun(t *testing.T) {
+	t.Run("json without errors", func(t *testing.T) {
+		found := format(t, config{}, `{"level":"INFO","msg":"ok"}`)
+		assert.Equal(t, "{\"level\":\"INFO\",\"msg\":\"ok\"}\n", found)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		logs := strings.Join([]string{
+			"panic: oops",
+			"",
+			"goroutine 1 [running]:",
+			"main.crash(...)",
+			"\t/home/ci/app/main.go:8",
+			"main.main()",
+			"\t/home/ci/app/main.go:4 +0x18",
+			"exit status 2",
+		}, "\n")
+
+		found := format(t, config{}, logs)
+		assert.Equal(t, strings.Join([]string{
+			"panic: oops",
+			"",
+			"goroutine 1 [running]:",
+			"  main.crash",
+			"      /home/ci/app/main.go:8",
+			"  main.main",
+			"      /home/ci/app/main.go:4",
+			"",
+			"exit status 2",
+			"",
+		}, "\n"), found)
+	})
+
+	t.Run("stdlib", func(t *testing.T) {
+		logs := strings.Join([]string{
+			"it broke",
+			"github.com/acme/app.handle",
+			"\t/home/ci/app/handler.go:8",
+			"net/http.HandlerFunc.ServeHTTP",
+			"\t/usr/local/go/src/net/http/server.go:2166",
+			"net/http.serverHandler.ServeHTTP",
+			"\t/usr/local/go/src/net/http/server.go:3137",
+		}, "\n")
+
+		found := format(t, config{}, logs)
+		assert.Contains(t, found, "  ... 2 standard library frames\n")
+		assert.NotContains(t, found, "ServeHTTP")
+
+		found = format(t, config{stdlib: true}, logs)
+		assert.Contains(t, found, "  net/http.HandlerFunc.ServeHTTP\n      net/http/server.go:2166\n")
+	})
+
+	t.Run("source", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "api"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "api", "handler.go"), []byte(strings.Join([]string{
+			"package api",
+			"",
+			"func handle() error {",
+			"\treturn errors.New(\"it broke\")",
+			"}",
+		}, "\n")), 0o644))
+
+		logs := strings.Join([]string{
+			"it broke",
+			"github.com/acme/app/api.handle",
+			"\t/home/ci/app/api/handler.go:4",
+		}, "\n")
+
+		found := format(t, config{module: "github.com/acme/app", src: dir, context: 1}, logs)
+		assert.Equal(t, strings.Join([]string{
+			"it broke",
+			"  github.com/acme/app/api.handle",
+			"      github.com/acme/app/api/handler.go:4",
+			"      3 | func handle() error {",
+			"    > 4 |     return errors.New(\"it broke\")",
+			"      5 | }",
+			"",
+			"",
+		}, "\n"), found)
+	})
+
+	t.Run("color", func(t *testing.T) {
+		found := format(t, config{color: true}, "it broke\nmain.main\n\t/app/main.go:4\n")
+		assert.Contains(t, found, ansi.Bold+ansi.Red+"it broke"+ansi.Reset)
+		assert.Contains(t, found, ansi.Cyan+"/app/main.go"+ansi.Reset+":"+ansi.Yellow+"4"+ansi.Reset)
+	})
+}
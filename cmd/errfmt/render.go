@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rclark/errors"
+	"github.com/rclark/errors/internal/ansi"
+)
+
+// printer renders the errors found in logs.
+type printer struct {
+	cfg   config
+	w     io.Writer
+	trim  func(errors.Frame) string
+	files map[string][]string
+	err   error
+}
+
+func (p *printer) setup() {
+	var opts []errors.TrimOption
+	if p.cfg.module != "" {
+		opts = append(opts, errors.TrimModuleRoot("", p.cfg.module))
+	}
+
+	p.trim = errors.TrimPaths(opts...)
+}
+
+func (p *printer) printf(format string, args ...any) {
+	if p.err != nil {
+		return
+	}
+
+	_, p.err = fmt.Fprintf(p.w, format, args...)
+}
+
+func (p *printer) paint(code, s string) string {
+	if !p.cfg.color || s == "" {
+		return s
+	}
+
+	return code + s + ansi.Reset
+}
+
+func (p *printer) print(e entry) {
+	if e.header != "" {
+		p.printf("%s ", p.paint(ansi.Dim, e.header))
+	}
+
+	p.printf("%s\n", p.paint(ansi.Bold+ansi.Red, e.message))
+
+	if len(e.fields) > 0 {
+		p.printf("  %s\n", p.paint(ansi.Dim, strings.Join(e.fields, " ")))
+	}
+
+	p.printStack(e.stack)
+
+	if !e.origin.IsZero() {
+		p.printf("%s\n", p.paint(ansi.Bold, "spawned from:"))
+		p.printStack(e.origin)
+	}
+
+	p.printf("\n")
+}
+
+func (p *printer) printStack(st errors.Stack) {
+	collapsed := 0
+	flush := func() {
+		switch collapsed {
+		case 0:
+			return
+		case 1:
+			p.printf("  %s\n", p.paint(ansi.Dim, "... 1 standard library frame"))
+		default:
+			p.printf("  %s\n", p.paint(ansi.Dim, fmt.Sprintf("... %d standard library frames", collapsed)))
+		}
+
+		collapsed = 0
+	}
+
+	for _, f := range st {
+		if !p.cfg.stdlib && f.IsStdlib() {
+			collapsed++
+			continue
+		}
+
+		flush()
+
+		inApp := p.inApp(f)
+		if inApp {
+			p.printf("  %s\n", p.paint(ansi.Bold, f.Function))
+		} else {
+			p.printf("  %s\n", f.Function)
+		}
+
+		p.printf("      %s:%s\n", p.paint(ansi.Cyan, p.trim(f)), p.paint(ansi.Yellow, fmt.Sprint(f.Line)))
+
+		if inApp {
+			p.printSource(f)
+		}
+	}
+
+	flush()
+}
+
+// printSource shows the lines of source code surrounding the frame's line, if
+// the file can be found in the checkout provided with -src.
+func (p *printer) printSource(f errors.Frame) {
+	if p.cfg.src == "" || p.cfg.context < 0 {
+		return
+	}
+
+	lines := p.source(f)
+	if f.Line < 1 || f.Line > len(lines) {
+		return
+	}
+
+	first := max(f.Line-p.cfg.context, 1)
+	last := min(f.Line+p.cfg.context, len(lines))
+	width := len(fmt.Sprint(last))
+
+	for n := first; n <= last; n++ {
+		text := strings.ReplaceAll(lines[n-1], "\t", "    ")
+		if n == f.Line {
+			p.printf("    %s %*d | %s\n", p.paint(ansi.Red, ">"), width, n, p.paint(ansi.Bold, text))
+			continue
+		}
+
+		p.printf("      %s\n", p.paint(ansi.Dim, fmt.Sprintf("%*d | %s", width, n, text)))
+	}
+}
+
+// source returns the lines of the frame's file within the checkout. Since the
+// file was compiled on another machine, its path is matched against the
+// checkout by trying ever shorter suffixes of it.
+func (p *printer) source(f errors.Frame) []string {
+	if lines, ok := p.files[f.File]; ok {
+		return lines
+	}
+
+	var candidates []string
+	if p.cfg.module != "" {
+		if rel, ok := strings.CutPrefix(p.trim(f), p.cfg.module+"/"); ok {
+			candidates = append(candidates, rel)
+		}
+	}
+
+	parts := strings.Split(filepath.ToSlash(f.File), "/")
+	for i := range parts {
+		candidates = append(candidates, strings.Join(parts[i:], "/"))
+	}
+
+	var lines []string
+	for _, c := range candidates {
+		b, err := os.ReadFile(filepath.Join(p.cfg.src, filepath.FromSlash(c)))
+		if err == nil {
+			lines = strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+			break
+		}
+	}
+
+	p.files[f.File] = lines
+	return lines
+}
+
+// inApp reports whether the frame belongs to the module that produced the
+// logs. When that module is not known, every frame outside of the standard
+// library and the module cache is considered to be in-app.
+func (p *printer) inApp(f errors.Frame) bool {
+	if p.cfg.module == "" {
+		return !f.IsStdlib() && !strings.Contains(f.File, "/pkg/mod/")
+	}
+
+	pkg := strings.TrimSuffix(f.Package(), "_test")
+	return pkg == "main" || pkg == p.cfg.module || strings.HasPrefix(pkg, p.cfg.module+"/")
+}
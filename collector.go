@@ -0,0 +1,187 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+const collectorShards = 16
+
+// Collector accumulates errors from many goroutines. Errors that share a
+// [Fingerprint], including the line numbers of their stack traces, are grouped
+// together, and only the first error in each group is retained. It is safe for
+// concurrent use.
+type Collector struct {
+	limit int64
+
+	shards   [collectorShards]collectorShard
+	seq      atomic.Int64
+	total    atomic.Int64
+	retained atomic.Int64
+	dropped  atomic.Int64
+}
+
+type collectorShard struct {
+	mu     sync.Mutex
+	groups map[string]*collected
+}
+
+type collected struct {
+	err      error
+	category string
+	seq      int64
+	count    int64
+}
+
+// NewCollector creates a [Collector] that retains at most limit distinct
+// errors. Errors that do not fit are counted as dropped. A limit less than or
+// equal to zero indicates no limit.
+func NewCollector(limit int) *Collector {
+	return &Collector{limit: int64(limit)}
+}
+
+// Add adds an error to the collector. Nil errors are ignored.
+func (c *Collector) Add(err error) {
+	if err == nil {
+		return
+	}
+
+	c.total.Add(1)
+
+	key := Fingerprint(err, IncludeLines())
+	i, _ := strconv.ParseUint(key[len(key)-1:], 16, 8)
+	shard := &c.shards[i%collectorShards]
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if g, ok := shard.groups[key]; ok {
+		g.count++
+		return
+	}
+
+	if c.limit > 0 && c.retained.Add(1) > c.limit {
+		c.retained.Add(-1)
+		c.dropped.Add(1)
+		return
+	}
+
+	if shard.groups == nil {
+		shard.groups = map[string]*collected{}
+	}
+
+	category, ok := Category(err)
+	if !ok {
+		category = "uncategorized"
+	}
+
+	shard.groups[key] = &collected{err: err, category: category, seq: c.seq.Add(1), count: 1}
+}
+
+// Dropped returns the number of errors that were not retained because the
+// collector's limit was reached.
+func (c *Collector) Dropped() int {
+	return int(c.dropped.Load())
+}
+
+// Err returns an error that summarizes every error added to the collector, or
+// nil if none were. It wraps the first error of each group, ordered from the
+// largest group to the smallest.
+//
+// When formatted with %+v, it describes the number of errors in each
+// [Category], followed by each group's count, the message of its first error
+// and that error's stack trace.
+func (c *Collector) Err() error {
+	e := collectedError{total: c.total.Load(), dropped: c.dropped.Load()}
+	if e.total == 0 {
+		return nil
+	}
+
+	for i := range c.shards {
+		shard := &c.shards[i]
+		shard.mu.Lock()
+		for _, g := range shard.groups {
+			e.groups = append(e.groups, *g)
+		}
+		shard.mu.Unlock()
+	}
+
+	sort.Slice(e.groups, func(i, j int) bool {
+		if e.groups[i].count != e.groups[j].count {
+			return e.groups[i].count > e.groups[j].count
+		}
+
+		return e.groups[i].seq < e.groups[j].seq
+	})
+
+	return e
+}
+
+type collectedError struct {
+	groups  []collected
+	total   int64
+	dropped int64
+}
+
+func (e collectedError) Error() string {
+	return Join(e.Unwrap()...).Error()
+}
+
+func (e collectedError) Unwrap() []error {
+	errs := make([]error, len(e.groups))
+	for i, g := range e.groups {
+		errs[i] = g.err
+	}
+
+	return errs
+}
+
+// Format formats the error according to the fmt.Formatter interface.
+//
+//   - %s    <message>\n...
+//   - %v    <message>\n...
+//   - %+v   <total> errors in <n> groups\n<category>: <count>\n...\n\n[<count>x] <%+v of error>\n\n...
+func (e collectedError) Format(s fmt.State, verb rune) {
+	if verb != 'v' || !s.Flag('+') {
+		_, _ = io.WriteString(s, e.Error())
+		return
+	}
+
+	fmt.Fprintf(s, "%d errors in %d groups", e.total, len(e.groups))
+	if e.dropped > 0 {
+		fmt.Fprintf(s, ", %d dropped", e.dropped)
+	}
+
+	categories := []string{}
+	counts := map[string]int64{}
+	for _, g := range e.groups {
+		if _, ok := counts[g.category]; !ok {
+			categories = append(categories, g.category)
+		}
+		counts[g.category] += g.count
+	}
+
+	for _, category := range categories {
+		_, _ = io.WriteString(s, "\n"+category+": "+strconv.FormatInt(counts[category], 10))
+	}
+
+	for _, g := range e.groups {
+		fmt.Fprintf(s, "\n\n[%dx] ", g.count)
+
+		if _, ok := g.err.(fmt.Formatter); ok {
+			fmt.Fprintf(s, "%+v", g.err)
+			continue
+		}
+
+		// Errors that do not format their own stack trace, such as those
+		// created by NewError, are followed by it.
+		_, _ = io.WriteString(s, g.err.Error())
+		if st, ok := StackTrace(g.err); ok {
+			fmt.Fprintf(s, "%+v", st)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	std "errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func invalidItem(i int) error {
+	err := errors.Errorf("item %d is invalid", i)
+	return errors.NewError[errors.BadInputError]("invalid item", errors.FromError(err))
+}
+
+func TestCollector(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		c := errors.NewCollector(0)
+		c.Add(nil)
+		assert.NoError(t, c.Err(), "should not return an error")
+	})
+
+	t.Run("groups duplicates", func(t *testing.T) {
+		c := errors.NewCollector(0)
+		for i := 0; i < 3; i++ {
+			c.Add(failedLookup(i))
+		}
+		c.Add(std.New("other"))
+
+		err := c.Err()
+		require.Error(t, err)
+
+		errs := errors.UnwrapAny(err)
+		require.Len(t, errs, 2, "should retain one error per group")
+		assert.EqualError(t, errs[0], "failed to find record 0", "should order groups by size and retain the first error")
+		assert.EqualError(t, errs[1], "other")
+		assert.Equal(t, "failed to find record 0\nother", err.Error())
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		c := errors.NewCollector(2)
+		c.Add(std.New("a"))
+		c.Add(std.New("b"))
+		c.Add(std.New("c"))
+		c.Add(std.New("a"))
+
+		assert.Equal(t, 1, c.Dropped(), "should drop errors beyond the limit")
+		assert.Len(t, errors.UnwrapAny(c.Err()), 2, "should retain errors up to the limit")
+		assert.Contains(t, fmt.Sprintf("%+v", c.Err()), "4 errors in 2 groups, 1 dropped")
+	})
+
+	t.Run("summary", func(t *testing.T) {
+		c := errors.NewCollector(0)
+		for i := 0; i < 3; i++ {
+			c.Add(invalidItem(i))
+		}
+		c.Add(errors.NewError[errors.MissingError]("missing"))
+		c.Add(std.New("other"))
+
+		found := fmt.Sprintf("%+v", c.Err())
+		blocks := strings.Split(found, "\n\n")
+		require.Len(t, blocks, 4)
+
+		assert.Equal(t, "5 errors in 3 groups\nBadInputError: 3\nMissingError: 1\nuncategorized: 1", blocks[0])
+		assert.True(t, strings.HasPrefix(blocks[1], "[3x] item 0 is invalid\ngithub.com/rclark/errors_test.invalidItem\n"), "should show the largest group first, with its stack")
+		assert.True(t, strings.HasPrefix(blocks[2], "[1x] missing\ngithub.com/rclark/errors_test.TestCollector"), "should show the stack of errors that do not format it")
+		assert.Equal(t, "[1x] other", blocks[3])
+	})
+
+	t.Run("representative stack", func(t *testing.T) {
+		c := errors.NewCollector(0)
+		c.Add(errors.Errorf("wrapped: %w", failedLookup(1)))
+
+		lines := strings.Split(fmt.Sprintf("%+v", c.Err()), "\n")
+		assert.Equal(t, "[1x] wrapped: failed to find record 1", lines[3])
+		assert.Equal(t, "github.com/rclark/errors_test.failedLookup", lines[4], "should show the stack of the representative error")
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		c := errors.NewCollector(10)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.Add(failedLookup(i))
+				c.Add(std.New(fmt.Sprintf("distinct %d", i)))
+			}()
+		}
+		wg.Wait()
+
+		errs := errors.UnwrapAny(c.Err())
+		assert.Len(t, errs, 10, "should retain errors up to the limit")
+		assert.Equal(t, 91, c.Dropped(), "should drop distinct errors beyond the limit")
+		assert.Contains(t, fmt.Sprintf("%+v", c.Err()), "200 errors in 10 groups")
+	})
+	t.Run("different lines", func(t *testing.T) {
+		c := errors.NewCollector(0)
+		c.Add(errors.New("oops"))
+		c.Add(errors.New("oops"))
+
+		assert.Len(t, errors.UnwrapAny(c.Err()), 2, "should not group errors created at different lines")
+	})
+}
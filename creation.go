@@ -0,0 +1,86 @@
+package errors
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+var recordCreation atomic.Bool
+
+// SetRecordCreation sets whether errors record the time they were created at
+// and the ID of the goroutine that created them, as reported by [CreatedAt] and
+// [GoroutineID]. Recording is disabled by default, since determining the
+// goroutine ID requires capturing the goroutine's stack trace a second time.
+func SetRecordCreation(enabled bool) {
+	recordCreation.Store(enabled)
+}
+
+// creation is the time and goroutine that an error was created in.
+type creation struct {
+	at        time.Time
+	goroutine uint64
+}
+
+func newCreation() creation {
+	if !recordCreation.Load() {
+		return creation{}
+	}
+
+	return creation{at: time.Now(), goroutine: goroutineID()}
+}
+
+// goroutineID returns the ID of the calling goroutine, which the runtime only
+// exposes in the header of the goroutine's stack trace, as in
+// "goroutine 7 [running]:".
+func goroutineID() uint64 {
+	var buf [64]byte
+	header := buf[:runtime.Stack(buf[:], false)]
+
+	header = bytes.TrimPrefix(header, []byte("goroutine "))
+	if space := bytes.IndexByte(header, ' '); space >= 0 {
+		header = header[:space]
+	}
+
+	id, _ := strconv.ParseUint(string(header), 10, 64)
+	return id
+}
+
+type creationRecorder interface {
+	creation() creation
+}
+
+func (e Error) creation() creation {
+	return e.created
+}
+
+// findCreation returns the first creation recorded by an error in the tree.
+func findCreation(err error) (creation, bool) {
+	if r, ok := err.(creationRecorder); ok && r.creation().goroutine != 0 {
+		return r.creation(), true
+	}
+
+	for _, child := range children(err) {
+		if c, ok := findCreation(child); ok {
+			return c, true
+		}
+	}
+
+	return creation{}, false
+}
+
+// CreatedAt returns the time that the error was created at, if it was recorded.
+// See [SetRecordCreation].
+func CreatedAt(err error) (time.Time, bool) {
+	c, ok := findCreation(err)
+	return c.at, ok
+}
+
+// GoroutineID returns the ID of the goroutine that created the error, if it was
+// recorded. See [SetRecordCreation].
+func GoroutineID(err error) (uint64, bool) {
+	c, ok := findCreation(err)
+	return c.goroutine, ok
+}
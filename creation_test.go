@@ -0,0 +1,114 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func currentGoroutine(t *testing.T) uint64 {
+	t.Helper()
+
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	field := strings.Fields(string(buf))[1]
+
+	id, err := strconv.ParseUint(field, 10, 64)
+	require.NoError(t, err)
+	return id
+}
+
+func TestRecordCreation(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		err := errors.New("oops")
+
+		_, ok := errors.CreatedAt(err)
+		assert.False(t, ok)
+
+		_, ok = errors.GoroutineID(err)
+		assert.False(t, ok)
+
+		assert.NotContains(t, fmt.Sprintf("%+v", err), "created at")
+	})
+
+	errors.SetRecordCreation(true)
+	defer errors.SetRecordCreation(false)
+
+	t.Run("enabled", func(t *testing.T) {
+		before := time.Now()
+		err := errors.New("oops")
+		after := time.Now()
+
+		at, ok := errors.CreatedAt(err)
+		require.True(t, ok)
+		assert.False(t, at.Before(before))
+		assert.False(t, at.After(after))
+
+		id, ok := errors.GoroutineID(err)
+		require.True(t, ok)
+		assert.Equal(t, currentGoroutine(t), id)
+
+		other := <-errors.Go(func() error { return errors.New("oops") })
+		otherID, ok := errors.GoroutineID(other)
+		require.True(t, ok)
+		assert.NotEqual(t, id, otherID)
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		err := errors.New("oops")
+		want, _ := errors.CreatedAt(err)
+
+		for _, wrapped := range []error{
+			errors.Errorf("wrapped: %w", err),
+			errors.NewError[errors.MissingError]("not found", errors.FromError(err)),
+			fmt.Errorf("wrapped: %w", err),
+		} {
+			at, ok := errors.CreatedAt(wrapped)
+			require.True(t, ok)
+			assert.Equal(t, want, at)
+		}
+	})
+
+	t.Run("format", func(t *testing.T) {
+		err := errors.New("oops")
+		at, _ := errors.CreatedAt(err)
+
+		lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+		assert.Equal(t, fmt.Sprintf("created at %s in goroutine %d", at.Format(time.RFC3339Nano), currentGoroutine(t)), lines[1])
+		assert.Equal(t, "github.com/rclark/errors_test.TestRecordCreation.func4", lines[2])
+
+		assert.True(t, strings.HasPrefix(fmt.Sprintf("%v", errors.PanicStyle(err)), fmt.Sprintf("panic: oops\n\ngoroutine %d [running]:\n", currentGoroutine(t))))
+	})
+
+	t.Run("serialized", func(t *testing.T) {
+		err := errors.New("oops")
+		at, _ := errors.CreatedAt(err)
+
+		b, jsonErr := json.Marshal(errors.NewReport(err))
+		require.NoError(t, jsonErr)
+
+		var report struct {
+			CreatedAt time.Time `json:"created_at"`
+			Goroutine uint64    `json:"goroutine"`
+		}
+		require.NoError(t, json.Unmarshal(b, &report))
+		assert.True(t, at.Equal(report.CreatedAt))
+		assert.Equal(t, currentGoroutine(t), report.Goroutine)
+
+		event := errors.NewSentryEvent(err)
+		assert.Equal(t, currentGoroutine(t), event.Extra["goroutine"])
+
+		attrs := errors.ExceptionAttributes(err)
+		assert.Contains(t, attrs, errors.Attribute{Key: "thread.id", Value: strconv.FormatUint(currentGoroutine(t), 10)})
+	})
+}
@@ -7,32 +7,87 @@ package errors
 
 import (
 	"fmt"
+	"io"
 	"runtime"
+	"slices"
+	"strconv"
+	"time"
 )
 
 // Error implements the error interface and provides a stack trace.
 type Error struct {
-	err     error
-	message string
-	stack   []Frame
+	err      error
+	message  string
+	template string
+	stack    []Frame
+	origin   []Frame
+	spawner  uint64
+	created  creation
 }
 
-func newError(message string, skip int) Error {
+// callers returns the stack of the calling goroutine. The argument skip has the
+// same meaning as for runtime.Callers, as if runtime.Callers was called in
+// place of callers.
+func callers(skip int) Stack {
 	var full [32]uintptr
-	n := runtime.Callers(skip, full[:])
-	frames := make([]Frame, n)
-	for i, pc := range full[:n] {
+	n := runtime.Callers(skip+1, full[:])
+	return stackFromPCs(full[:n])
+}
+
+// stackFromPCs creates a [Stack] from program counters, as returned by
+// runtime.Callers. Identical stacks are shared, see [SetStackCacheSize].
+func stackFromPCs(pcs []uintptr) Stack {
+	return stacks.intern(pcs)
+}
+
+// newStack resolves the frames of program counters.
+func newStack(pcs []uintptr) Stack {
+	frames := make([]Frame, len(pcs))
+	for i, pc := range pcs {
 		frames[i] = newFrame(pc)
 	}
 
-	return Error{
-		message: message,
-		stack:   frames,
+	return frames
+}
+
+// newError creates an [Error] with a stack trace, if one should be captured for
+// an error in the category of the options according to the [CapturePolicy].
+//
+// The stack trace starts at the first caller outside of this package, so that
+// the functions that create errors do not need to count the frames between
+// them and their callers. Only the legacy [Skip] option counts frames from
+// here, with the same meaning as for callers.
+func newError(message string, o options) Error {
+	e := Error{
+		message:  message,
+		template: message,
+		created:  newCreation(),
+	}
+
+	if !shouldCapture(o.category) {
+		return e
+	}
+
+	if o.skip > 0 {
+		e.stack = trimStack(callers(o.skip+o.frames), o)
+		return e
+	}
+
+	st := callers(2)
+	for len(st) > 0 && isOwnPackage(st[0]) {
+		st = st[1:]
 	}
+
+	e.stack = trimStack(st[min(max(o.frames, 0), len(st)):], o)
+	return e
 }
 
-func wrapError(err error, skip int) Error {
-	e := newError(err.Error(), skip+1)
+func wrapError(err error, o options) Error {
+	if o.skip > 0 {
+		o.skip++
+	}
+
+	e := newError(err.Error(), o)
 	e.err = err
 	return e
 }
@@ -42,9 +97,10 @@ func (e Error) Error() string {
 	return e.message
 }
 
-// StackTrace returns the [Stack].
+// StackTrace returns a copy of the [Stack], so changing it does not affect
+// other errors created at the same place.
 func (e Error) StackTrace() Stack {
-	return e.stack
+	return slices.Clone(e.stack)
 }
 
 // Unwrap returns the wrapped error, if any.
@@ -58,14 +114,105 @@ func (e Error) Unwrap() error {
 //   - %+s   <message>: [<filename:line> ...]
 //   - %v    <message>
 //   - %+v   <message>\n<package>.<function>\n\t<filepath>:<line>\n\t...
+//   - %#v   as rendered by [Pretty]
+//
+// When [SetRecordCreation] is enabled, %+v follows the message with the time
+// and goroutine that the error was created in, as in
+// "created at 2024-06-01T12:00:00Z in goroutine 7".
+//
+// The layout of %+v can be replaced with [SetDefaultFormatter].
+//
+// If the error was returned from a goroutine started by [Go], [GoContext] or
+// [Group.Go], the stack trace of the place that started the goroutine follows,
+// introduced by "spawned from:".
+//
+// With %+v, each wrapped error that has a stack trace of its own, such as when
+// the [Overwrite] option is used, follows after "caused by:". Only the frames
+// that differ from the stack trace of the error that wraps it are shown, as in
+// "(N frames in common)".
 func (e Error) Format(s fmt.State, verb rune) {
+	if f := defaultFormatter.Load(); f != nil && verb == 'v' && s.Flag('+') && !s.Flag('#') {
+		if err := f.Render(s, e); err != nil {
+			_, _ = io.WriteString(s, "%!v(errors.Formatter="+err.Error()+")")
+		}
+		return
+	}
+
+	e.format(s, verb)
+}
+
+// format formats the error with the built-in layouts, ignoring the formatter
+// set by [SetDefaultFormatter].
+func (e Error) format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('#') {
+		Pretty(e).Format(s, verb)
+		return
+	}
+
 	_, _ = s.Write([]byte(e.Error()))
 
-	if s.Flag('+') {
+	if !s.Flag('+') {
+		return
+	}
+
+	if c := e.created; verb == 'v' && c.goroutine != 0 {
+		_, _ = io.WriteString(s, "\ncreated at "+c.at.Format(time.RFC3339Nano)+" in goroutine "+strconv.FormatUint(c.goroutine, 10))
+	}
+
+	if !Stack(e.stack).IsZero() {
 		if verb == 's' {
 			_, _ = s.Write([]byte(": "))
 		}
 
 		Stack(e.stack).Format(s, verb)
 	}
+
+	if !Stack(e.origin).IsZero() {
+		switch verb {
+		case 's':
+			_, _ = s.Write([]byte(" spawned from: "))
+		case 'v':
+			_, _ = s.Write([]byte("\nspawned from:"))
+		}
+
+		Stack(e.origin).Format(s, verb)
+	}
+
+	if verb == 'v' {
+		e.formatCauses(s)
+	}
+}
+
+// formatCauses writes the stack traces of the wrapped errors that have stack
+// traces of their own, leaving out the frames they have in common with the
+// stack trace of the error that wraps them.
+func (e Error) formatCauses(w io.Writer) {
+	outer := Stack(e.stack).filtered()
+
+	for err := e.err; err != nil; {
+		if st, ok := ownStack(err); ok && !st.IsZero() {
+			if st = st.filtered(); !sameStack(st, outer) {
+				_, _ = io.WriteString(w, "\ncaused by: "+err.Error())
+				for _, f := range st.Diff(outer) {
+					_, _ = io.WriteString(w, "\n"+f.long())
+				}
+
+				switch n := st.CommonSuffix(outer); n {
+				case 0:
+				case 1:
+					_, _ = io.WriteString(w, "\n(1 frame in common)")
+				default:
+					_, _ = io.WriteString(w, "\n("+strconv.Itoa(n)+" frames in common)")
+				}
+
+				outer = st
+			}
+		}
+
+		next := children(err)
+		if len(next) == 0 {
+			break
+		}
+		err = next[0]
+	}
 }
@@ -1,3 +1,5 @@
+//go:build !errors_nocapture
+
 package errors_test
 
 import (
@@ -7,6 +9,7 @@ import (
 
 	"github.com/rclark/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestErrorFormat(t *testing.T) {
@@ -29,3 +32,24 @@ func TestErrorFormat(t *testing.T) {
 	expect = fmt.Sprintf("the error message: [error_test.go:%d testing.go:", line)
 	assert.Contains(t, buf.String(), expect, "%s should contain the error message and file path")
 }
+
+func TestErrorFormatCauses(t *testing.T) {
+	inner := func() error {
+		return errors.New("inner")
+	}
+
+	err := errors.Errorf("outer: %w", inner(), errors.Overwrite())
+
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+	require.Len(t, lines, 11)
+	assert.Equal(t, "outer: inner", lines[0])
+	assert.Equal(t, "github.com/rclark/errors_test.TestErrorFormatCauses", lines[1])
+	assert.Equal(t, "testing.tRunner", lines[3])
+	assert.Equal(t, "runtime.goexit", lines[5])
+	assert.Equal(t, "caused by: inner", lines[7])
+	assert.Equal(t, "github.com/rclark/errors_test.TestErrorFormatCauses.func1", lines[8])
+	assert.Equal(t, "(3 frames in common)", lines[10])
+
+	assert.NotContains(t, fmt.Sprintf("%+v", errors.Errorf("outer: %w", inner())), "caused by", "errors sharing a stack trace have no causes")
+	assert.NotContains(t, fmt.Sprintf("%+s", err), "caused by")
+}
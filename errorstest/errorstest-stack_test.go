@@ -0,0 +1,90 @@
+//go:build !errors_nocapture
+
+package errorstest_test
+
+import (
+	std "errors"
+	"fmt"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/rclark/errors/errorstest"
+	"github.com/stretchr/testify/assert"
+)
+
+func load() error {
+	return errors.New("no rows")
+}
+
+func TestAssertStackContains(t *testing.T) {
+	err := load()
+
+	for _, fn := range []any{
+		load,
+		"github.com/rclark/errors/errorstest_test.load",
+		"errorstest_test.load",
+		"errorstest_test.TestAssertStackContains",
+		"testing.tRunner",
+	} {
+		ok, failure := check(t, func(tb testing.TB) bool {
+			return errorstest.AssertStackContains(tb, err, fn)
+		})
+		assert.True(t, ok, failure)
+	}
+
+	ok, failure := check(t, func(tb testing.TB) bool {
+		return errorstest.AssertStackContains(tb, err, TestAssertCategory)
+	})
+	assert.False(t, ok)
+	assert.Contains(t, failure, "expected a stack trace containing github.com/rclark/errors/errorstest_test.TestAssertCategory")
+
+	ok, failure = check(t, func(tb testing.TB) bool {
+		return errorstest.AssertStackContains(tb, std.New("no rows"), load)
+	})
+	assert.False(t, ok)
+	assert.Contains(t, failure, "but there is none")
+
+	ok, failure = check(t, func(tb testing.TB) bool {
+		return errorstest.AssertStackContains(tb, err, 42)
+	})
+	assert.False(t, ok)
+	assert.Contains(t, failure, "expected a function or function name, but got int")
+}
+
+func TestAssertNoStackLeak(t *testing.T) {
+	ok, _ := check(t, func(tb testing.TB) bool {
+		return errorstest.AssertNoStackLeak(tb, errors.NewError[errors.MissingError]("The item was not found."))
+	})
+	assert.True(t, ok)
+
+	ok, _ = check(t, func(tb testing.TB) bool {
+		return errorstest.AssertNoStackLeak(tb, nil)
+	})
+	assert.True(t, ok)
+
+	leaked := errors.New("no rows")
+	for name, err := range map[string]error{
+		"location":  errors.NewError[errors.MissingError](fmt.Sprintf("Not found: %+s", leaked)),
+		"function":  errors.NewUserFacingError(fmt.Sprintf("%v", errors.Pretty(leaked, errors.Color(false)))),
+		"goroutine": errors.NewUserFacingError(fmt.Sprintf("%v", errors.PanicStyle(leaked)), errors.FromError(std.New("panic"))),
+		"wrapped":   errors.Errorf("loading: %+v", leaked),
+	} {
+		ok, failure := check(t, func(tb testing.TB) bool {
+			return errorstest.AssertNoStackLeak(tb, err)
+		})
+		assert.False(t, ok, name)
+		assert.Contains(t, failure, "expected no stack trace in", name)
+	}
+}
+
+func TestAssertShowsStack(t *testing.T) {
+	_, failure := check(t, func(tb testing.TB) bool {
+		return errorstest.AssertCategory[errors.BadInputError](tb, errors.NewError[errors.MissingError]("not found"))
+	})
+	assert.Contains(t, failure, "\nerror: not found\ngithub.com/rclark/errors/errorstest_test.TestAssertShowsStack.func1\n", "the full error should be shown")
+
+	_, failure = check(t, func(tb testing.TB) bool {
+		return errorstest.AssertWraps(tb, errors.Errorf("loading: %w", std.New("no rows")), std.New("no rows"))
+	})
+	assert.Contains(t, failure, "\nerror: loading: no rows\ngithub.com/rclark/errors/errorstest_test.TestAssertShowsStack.func2\n")
+}
@@ -0,0 +1,152 @@
+/*
+Package errorstest provides test assertions for errors created by
+github.com/rclark/errors. Each assertion marks the test as failed when it does
+not hold, reports the full %+v rendering of the error to help find where it
+came from, and returns whether it held.
+
+	func TestLoad(t *testing.T) {
+		err := load("missing-id")
+		errorstest.AssertCategory[errors.MissingError](t, err)
+		errorstest.AssertUserMessage(t, err, "The item was not found.")
+	}
+*/
+package errorstest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/rclark/errors/internal/funcname"
+)
+
+// AssertCategory asserts that err's tree contains an error of the
+// [errors.ErrorType] T, such as [errors.MissingError].
+func AssertCategory[T errors.ErrorType](tb testing.TB, err error) bool {
+	tb.Helper()
+
+	var target T
+	if errors.As(err, &target) {
+		return true
+	}
+
+	return fail(tb, err, "expected an error of type %T", target)
+}
+
+// AssertUserMessage asserts that err carries the provided message for a user
+// external to the system, as returned by [errors.UserFacingMessage].
+func AssertUserMessage(tb testing.TB, err error, want string) bool {
+	tb.Helper()
+
+	got, ok := errors.UserFacingMessage(err)
+	if !ok {
+		return fail(tb, err, "expected a user-facing message %q, but there is none", want)
+	}
+
+	if got != want {
+		return fail(tb, err, "expected the user-facing message %q, but it is %q", want, got)
+	}
+
+	return true
+}
+
+// AssertStackContains asserts that err's [errors.Stack] contains a frame of
+// the provided function. The function is either a function value or the name
+// of a function, which is either fully qualified, as in
+// "github.com/org/repo/pkg.Load", or is qualified by its package name alone,
+// as in "pkg.Load".
+func AssertStackContains(tb testing.TB, err error, fn any) bool {
+	tb.Helper()
+
+	name, ok := fn.(string)
+	if !ok {
+		name = funcname.Of(fn)
+	}
+
+	if name == "" {
+		return fail(tb, err, "expected a function or function name, but got %T", fn)
+	}
+
+	st, ok := errors.StackTrace(err)
+	if !ok {
+		return fail(tb, err, "expected a stack trace containing %s, but there is none", name)
+	}
+
+	for _, f := range st {
+		if f.Function == name || strings.HasSuffix(f.Function, "/"+name) {
+			return true
+		}
+	}
+
+	return fail(tb, err, "expected a stack trace containing %s", name)
+}
+
+// AssertWraps asserts that err's tree contains an error that matches target,
+// as reported by [errors.Is].
+func AssertWraps(tb testing.TB, err, target error) bool {
+	tb.Helper()
+
+	if errors.Is(err, target) {
+		return true
+	}
+
+	return fail(tb, err, "expected an error wrapping %q", target)
+}
+
+// location matches file paths with line numbers, as in "/app/main.go:12".
+var location = regexp.MustCompile(`\.go:\d+`)
+
+// AssertNoStackLeak asserts that neither the error message nor the user-facing
+// message of err reveal its stack trace: they contain no file paths with line
+// numbers, no goroutine headers, and no names of the functions in its stack.
+func AssertNoStackLeak(tb testing.TB, err error) bool {
+	tb.Helper()
+
+	if err == nil {
+		return true
+	}
+
+	messages := []string{err.Error(), fmt.Sprintf("%v", err)}
+	if msg, ok := errors.UserFacingMessage(err); ok {
+		messages = append(messages, msg)
+	}
+
+	st, _ := errors.StackTrace(err)
+	for _, msg := range messages {
+		if loc := location.FindString(msg); loc != "" {
+			return fail(tb, err, "expected no stack trace in %q, but it contains the location %q", msg, loc)
+		}
+
+		if strings.Contains(msg, "goroutine ") && strings.Contains(msg, "[running]") {
+			return fail(tb, err, "expected no stack trace in %q, but it contains a goroutine header", msg)
+		}
+
+		for _, f := range st {
+			if f.Function != "" && strings.Contains(msg, f.Function) {
+				return fail(tb, err, "expected no stack trace in %q, but it contains the function %s", msg, f.Function)
+			}
+		}
+	}
+
+	return true
+}
+
+func fail(tb testing.TB, err error, format string, args ...any) bool {
+	tb.Helper()
+
+	tb.Errorf("%s\nerror: %s", fmt.Sprintf(format, args...), render(err))
+	return false
+}
+
+// render formats err with %+v. The stack trace of errors that do not format
+// it themselves, such as those created by [errors.NewError], is added.
+func render(err error) string {
+	if _, ok := err.(fmt.Formatter); ok || err == nil {
+		return fmt.Sprintf("%+v", err)
+	}
+
+	st, _ := errors.StackTrace(err)
+	return fmt.Sprintf("%s%+v", err, st)
+}
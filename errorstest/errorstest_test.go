@@ -0,0 +1,89 @@
+package errorstest_test
+
+import (
+	std "errors"
+	"fmt"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/rclark/errors/errorstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTB records failures instead of failing the test that uses it.
+type fakeTB struct {
+	testing.TB
+	failures []string
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Errorf(format string, args ...any) {
+	tb.failures = append(tb.failures, fmt.Sprintf(format, args...))
+}
+
+func check(t *testing.T, assertion func(tb testing.TB) bool) (bool, string) {
+	t.Helper()
+
+	tb := &fakeTB{TB: t}
+	ok := assertion(tb)
+	require.Equal(t, ok, len(tb.failures) == 0, "an assertion should fail exactly when it reports failure")
+
+	if ok {
+		return true, ""
+	}
+	return false, tb.failures[0]
+}
+
+func TestAssertCategory(t *testing.T) {
+	err := errors.NewError[errors.MissingError]("not found")
+
+	ok, _ := check(t, func(tb testing.TB) bool {
+		return errorstest.AssertCategory[errors.MissingError](tb, errors.Errorf("wrapped: %w", err))
+	})
+	assert.True(t, ok)
+
+	ok, failure := check(t, func(tb testing.TB) bool {
+		return errorstest.AssertCategory[errors.BadInputError](tb, err)
+	})
+	assert.False(t, ok)
+	assert.Contains(t, failure, "expected an error of type errors.BadInputError")
+}
+
+func TestAssertUserMessage(t *testing.T) {
+	err := errors.NewError[errors.MissingError]("The item was not found.", errors.FromError(errors.New("no rows")))
+
+	ok, _ := check(t, func(tb testing.TB) bool {
+		return errorstest.AssertUserMessage(tb, err, "The item was not found.")
+	})
+	assert.True(t, ok)
+
+	ok, failure := check(t, func(tb testing.TB) bool {
+		return errorstest.AssertUserMessage(tb, err, "Not found.")
+	})
+	assert.False(t, ok)
+	assert.Contains(t, failure, `expected the user-facing message "Not found.", but it is "The item was not found."`)
+
+	ok, failure = check(t, func(tb testing.TB) bool {
+		return errorstest.AssertUserMessage(tb, errors.New("no rows"), "Not found.")
+	})
+	assert.False(t, ok)
+	assert.Contains(t, failure, "but there is none")
+}
+
+func TestAssertWraps(t *testing.T) {
+	target := std.New("no rows")
+	err := errors.Errorf("loading: %w", target)
+
+	ok, _ := check(t, func(tb testing.TB) bool {
+		return errorstest.AssertWraps(tb, err, target)
+	})
+	assert.True(t, ok)
+
+	ok, failure := check(t, func(tb testing.TB) bool {
+		return errorstest.AssertWraps(tb, err, std.New("no rows"))
+	})
+	assert.False(t, ok)
+	assert.Contains(t, failure, `expected an error wrapping "no rows"`)
+}
@@ -0,0 +1,73 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	std "errors"
+	"fmt"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func failedLookupTwice(id int) (error, error) {
+	first := errors.Errorf("failed to find record %d", id)
+	second := errors.Errorf("failed to find record %d", id)
+	return first, second
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Run("same call site with different values", func(t *testing.T) {
+		fingerprints := make([]string, 2)
+		for i := range fingerprints {
+			fingerprints[i] = errors.Fingerprint(failedLookup(i))
+		}
+
+		a, b := fingerprints[0], fingerprints[1]
+		assert.Equal(t, a, b, "interpolated values should not affect the fingerprint")
+	})
+
+	t.Run("different call sites", func(t *testing.T) {
+		a := errors.Fingerprint(failedLookup(1))
+		b := errors.Fingerprint(errors.Errorf("failed to find record %d", 1))
+		assert.NotEqual(t, a, b, "different call sites should have different fingerprints")
+	})
+
+	t.Run("different templates", func(t *testing.T) {
+		a := errors.Fingerprint(errors.New("a"))
+		b := errors.Fingerprint(errors.New("b"))
+		assert.NotEqual(t, a, b, "different messages should have different fingerprints")
+	})
+
+	t.Run("different categories", func(t *testing.T) {
+		a := errors.Fingerprint(errors.NewError[errors.BadInputError]("failed"))
+		b := errors.Fingerprint(errors.NewError[errors.MissingError]("failed"))
+		assert.NotEqual(t, a, b, "different categories should have different fingerprints")
+	})
+
+	t.Run("include lines", func(t *testing.T) {
+		a, b := failedLookupTwice(1)
+		assert.Equal(t, errors.Fingerprint(a), errors.Fingerprint(b), "line numbers should be ignored by default")
+		assert.NotEqual(t, errors.Fingerprint(a, errors.IncludeLines()), errors.Fingerprint(b, errors.IncludeLines()), "line numbers should be considered")
+	})
+
+	t.Run("frame count", func(t *testing.T) {
+		a, b := failedLookupTwice(1)
+		a = errors.Errorf("wrapped: %w", a)
+		b = errors.Errorf("wrapped: %w", b)
+		opts := []errors.FingerprintOption{errors.FingerprintFrames(0), errors.IncludeLines()}
+		assert.Equal(t, errors.Fingerprint(a, opts...), errors.Fingerprint(b, opts...), "no frames should be considered")
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		assert.Empty(t, errors.Fingerprint(nil))
+	})
+
+	t.Run("without stack trace", func(t *testing.T) {
+		a := errors.Fingerprint(std.New("a"))
+		b := errors.Fingerprint(fmt.Errorf("a"))
+		assert.Equal(t, a, b, "should fingerprint the message")
+		assert.Len(t, a, 16)
+	})
+}
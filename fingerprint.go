@@ -0,0 +1,100 @@
+package errors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strconv"
+)
+
+type fingerprintOptions struct {
+	frames       int
+	includeLines bool
+}
+
+// FingerprintOption configures the calculation of a [Fingerprint].
+type FingerprintOption func(*fingerprintOptions)
+
+// FingerprintFrames sets the number of stack frames that contribute to a
+// [Fingerprint]. The default is 5.
+func FingerprintFrames(n int) FingerprintOption {
+	return func(o *fingerprintOptions) {
+		o.frames = n
+	}
+}
+
+// IncludeLines adds the line numbers of stack frames to a [Fingerprint], so
+// that errors created at different lines of the same function are told apart.
+// The fingerprint then changes whenever code above those lines is edited.
+func IncludeLines() FingerprintOption {
+	return func(o *fingerprintOptions) {
+		o.includeLines = true
+	}
+}
+
+// Fingerprint returns a stable identifier for err that can be used to group
+// and deduplicate identical failures.
+//
+// The fingerprint is a hash of the error's [Category], its message template and
+// the function names of the top frames of its [Stack] that belong to the main
+// module. The message template is the format string given to [Errorf] or the
+// message given to [New], so interpolated values do not affect the result.
+// Line numbers are ignored unless [IncludeLines] is provided, so that the same
+// failure has the same fingerprint across deploys. A nil error has an empty
+// fingerprint.
+func Fingerprint(err error, opts ...FingerprintOption) string {
+	if err == nil {
+		return ""
+	}
+
+	o := fingerprintOptions{frames: 5}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	h := sha256.New()
+
+	category, _ := Category(err)
+	_, _ = io.WriteString(h, category+"\n")
+
+	template := err.Error()
+	var e Error
+	if As(err, &e) {
+		template = e.template
+	}
+	_, _ = io.WriteString(h, template+"\n")
+
+	stack, _ := StackTrace(err)
+	for _, f := range fingerprintFrames(stack, o.frames) {
+		_, _ = io.WriteString(h, f.Function)
+		if o.includeLines {
+			_, _ = io.WriteString(h, ":"+strconv.Itoa(f.Line))
+		}
+		_, _ = io.WriteString(h, "\n")
+	}
+
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// fingerprintFrames selects up to n frames from the top of the stack that
+// belong to the main module. If there are none, the top n frames are used.
+func fingerprintFrames(st Stack, n int) Stack {
+	module := mainModule()
+
+	frames := make(Stack, 0, n)
+	for _, f := range st {
+		if len(frames) == n {
+			break
+		}
+
+		if inModule(f, module) {
+			frames = append(frames, f)
+		}
+	}
+
+	if len(frames) == 0 && len(st) > 0 {
+		frames = st[:min(n, len(st))]
+	}
+
+	return frames
+}
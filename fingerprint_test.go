@@ -0,0 +1,23 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/rclark/errors"
+)
+
+func failedLookup(id int) error {
+	return errors.Errorf("failed to find record %d", id)
+}
+
+func ExampleFingerprint() {
+	seen := map[string]int{}
+	for i := 0; i < 3; i++ {
+		err := failedLookup(i)
+		seen[errors.Fingerprint(err)]++
+	}
+
+	fmt.Println(len(seen))
+
+	// Output: 1
+}
@@ -0,0 +1,90 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatter(t *testing.T) {
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := errors.NewFormatter("{{.Message")
+		assert.ErrorContains(t, err, "invalid formatter template")
+		assert.Panics(t, func() { errors.MustFormatter("{{.Message") })
+	})
+
+	t.Run("render", func(t *testing.T) {
+		f, err := errors.NewFormatter(`{{.Message}}{{range .Stack}}|{{.FuncName}}{{if inApp .}}*{{end}}{{end}}`)
+		require.NoError(t, err)
+
+		var b strings.Builder
+		require.NoError(t, f.Render(&b, errors.New("oops")))
+		assert.True(t, strings.HasPrefix(b.String(), "oops|TestFormatter.func2*|tRunner|"), b.String())
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		f := errors.MustFormatter(`{{.Missing}}`)
+
+		var b strings.Builder
+		assert.Error(t, f.Render(&b, errors.New("oops")))
+		assert.Empty(t, b.String())
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		var b strings.Builder
+		require.NoError(t, errors.CompactFormatter.Render(&b, parsedError(t)))
+		assert.Equal(t, "oops at db.go:12 < main.go:30", b.String())
+
+		b.Reset()
+		err := errors.NewError[errors.MissingError]("not found")
+		require.NoError(t, errors.CompactFormatter.Render(&b, err))
+		assert.True(t, strings.HasPrefix(b.String(), "not found [MissingError] at formatter-stack_test.go:"), b.String())
+	})
+
+	t.Run("verbose", func(t *testing.T) {
+		var b strings.Builder
+		require.NoError(t, errors.VerboseFormatter.Render(&b, parsedError(t)))
+		assert.Equal(t, strings.Join([]string{
+			"oops",
+			"main.load",
+			"\t/app/db.go:12",
+			"main.main",
+			"\t/app/main.go:30",
+			"spawned from:",
+			"main.start",
+			"\t/app/main.go:8",
+		}, "\n"), b.String())
+
+		b.Reset()
+		err := errors.NewError[errors.MissingError]("not found")
+		require.NoError(t, errors.VerboseFormatter.Render(&b, err))
+		assert.True(t, strings.HasPrefix(b.String(), "not found\ncategory: MissingError\nuser message: not found\n"), b.String())
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		var b strings.Builder
+		require.NoError(t, errors.PanicFormatter.Render(&b, parsedError(t)))
+		assert.Equal(t, strings.Join([]string{
+			"panic: oops",
+			"",
+			"goroutine 1 [running]:",
+			"main.load(...)",
+			"\t/app/db.go:12",
+			"main.main(...)",
+			"\t/app/main.go:30",
+			"created by main.start",
+			"\t/app/main.go:8",
+		}, "\n"), b.String())
+
+		b.Reset()
+		err := <-errors.Go(func() error { return errors.New("oops") })
+		require.NoError(t, errors.PanicFormatter.Render(&b, err))
+		assert.Equal(t, fmt.Sprint(errors.PanicStyle(err)), b.String(), "should render as PanicStyle does")
+	})
+}
@@ -0,0 +1,176 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"text/template"
+	"time"
+)
+
+// FormatData is the data that the template of a [Formatter] is executed with.
+type FormatData struct {
+	// Err is the error being formatted. An [Error] formatted with %+v inside
+	// of a template uses the built-in layout, rather than the formatter set by
+	// [SetDefaultFormatter], so that it is not rendered by the template again.
+	Err error
+
+	// Message is the error message.
+	Message string
+
+	// UserMessage is the error's [UserFacingMessage], if any.
+	UserMessage string
+
+	// Category is the error's [Category], if any.
+	Category string
+
+	// Stack is the error's [Stack], after the filter set by [SetDefaultFilter]
+	// has been applied.
+	Stack Stack
+
+	// SpawnedFrom is the stack trace reported by [SpawnedFrom], after the filter
+	// set by [SetDefaultFilter] has been applied.
+	SpawnedFrom Stack
+
+	// CreatedAt is the time reported by [CreatedAt], if any.
+	CreatedAt time.Time
+
+	// Goroutine is the goroutine ID reported by [GoroutineID], or zero.
+	Goroutine uint64
+}
+
+// Formatter renders errors with a text/template. See [NewFormatter].
+type Formatter struct {
+	tmpl *template.Template
+}
+
+// NewFormatter creates a [Formatter] from a text/template definition, which is
+// executed with [FormatData]. In addition to the template package's built-in
+// functions, the function inApp reports whether a [Frame] belongs to the main
+// module, and the function panicStyle renders an error as described by
+// [PanicStyle].
+//
+//	f, err := errors.NewFormatter(`{{.Message}}{{range .Stack}} {{.ShortFile}}:{{.Line}}{{end}}`)
+func NewFormatter(text string) (*Formatter, error) {
+	tmpl, err := template.New("errors").Funcs(template.FuncMap{
+		"inApp": func(f Frame) bool {
+			return inModule(f, mainModule())
+		},
+		"panicStyle": func(err error) string {
+			return fmt.Sprint(PanicStyle(err))
+		},
+	}).Parse(text)
+	if err != nil {
+		return nil, Errorf("invalid formatter template: %w", err)
+	}
+
+	return &Formatter{tmpl: tmpl}, nil
+}
+
+// MustFormatter is like [NewFormatter] but panics if the template is invalid.
+func MustFormatter(text string) *Formatter {
+	f, err := NewFormatter(text)
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+}
+
+// Render writes err to w as rendered by the formatter's template. A nil err is
+// written as "<nil>".
+func (f *Formatter) Render(w io.Writer, err error) error {
+	if err == nil {
+		_, writeErr := io.WriteString(w, "<nil>")
+		return writeErr
+	}
+
+	data := FormatData{Err: templateError{err: err}, Message: err.Error()}
+	data.UserMessage, _ = UserFacingMessage(err)
+	data.Category, _ = Category(err)
+
+	st, _ := StackTrace(err)
+	data.Stack = st.filtered()
+
+	origin, _ := SpawnedFrom(err)
+	data.SpawnedFrom = origin.filtered()
+
+	if c, ok := findCreation(err); ok {
+		data.CreatedAt, data.Goroutine = c.at, c.goroutine
+	}
+
+	b := strings.Builder{}
+	if execErr := f.tmpl.Execute(&b, data); execErr != nil {
+		return WithStack(execErr)
+	}
+
+	_, writeErr := io.WriteString(w, b.String())
+	return writeErr
+}
+
+// templateError is the error that templates are executed with. It formats an
+// [Error] with the built-in layouts, since formatting it with %+v would
+// otherwise render it with the default formatter, and so with the template,
+// again.
+type templateError struct {
+	err error
+}
+
+func (e templateError) Error() string {
+	return e.err.Error()
+}
+
+func (e templateError) Unwrap() error {
+	return e.err
+}
+
+func (e templateError) Format(s fmt.State, verb rune) {
+	if err, ok := e.err.(Error); ok {
+		err.format(s, verb)
+		return
+	}
+
+	_, _ = fmt.Fprintf(s, fmt.FormatString(s, verb), e.err)
+}
+
+var (
+	// CompactFormatter renders an error on a single line, listing the file
+	// name and line number of each frame, as in
+	// "not found [MissingError] at db.go:12 < handler.go:30".
+	CompactFormatter = MustFormatter(
+		`{{.Message}}{{with .Category}} [{{.}}]{{end}}` +
+			`{{range $i, $f := .Stack}}{{if $i}} <{{else}} at{{end}} {{$f.ShortFile}}:{{$f.Line}}{{end}}`,
+	)
+
+	// VerboseFormatter renders an error's message, category, user-facing
+	// message, stack trace and the stack trace reported by [SpawnedFrom], each
+	// on their own lines.
+	VerboseFormatter = MustFormatter(`{{.Message}}
+{{- with .Category}}
+category: {{.}}{{end}}
+{{- with .UserMessage}}
+user message: {{.}}{{end}}
+{{- range .Stack}}
+{{.Function}}
+	{{.Path}}:{{.Line}}{{end}}
+{{- with .SpawnedFrom}}
+spawned from:{{range .}}
+{{.Function}}
+	{{.Path}}:{{.Line}}{{end}}{{end}}`)
+
+	// PanicFormatter renders an error in the layout that the Go runtime uses
+	// when a program panics, as described by [PanicStyle].
+	PanicFormatter = MustFormatter(`{{panicStyle .Err}}`)
+)
+
+var defaultFormatter atomic.Pointer[Formatter]
+
+// SetDefaultFormatter sets the [Formatter] that [Error] uses when it is
+// formatted with %+v. Providing nil restores the built-in layout, which is the
+// default.
+//
+//	errors.SetDefaultFormatter(errors.CompactFormatter)
+func SetDefaultFormatter(f *Formatter) {
+	defaultFormatter.Store(f)
+}
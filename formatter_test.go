@@ -0,0 +1,71 @@
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleSetDefaultFormatter() {
+	errors.SetDefaultFormatter(errors.MustFormatter(`{{.Message}} ({{len .Stack}} frames)`))
+	defer errors.SetDefaultFormatter(nil)
+
+	err, _ := errors.ParseError("oops\nmain.load\n\t/app/db.go:12\nmain.main\n\t/app/main.go:30")
+	fmt.Printf("%+v\n", err)
+	// Output: oops (2 frames)
+}
+
+func parsedError(t *testing.T) error {
+	t.Helper()
+
+	err, parseErr := errors.ParseError(strings.Join([]string{
+		"oops",
+		"main.load",
+		"\t/app/db.go:12",
+		"main.main",
+		"\t/app/main.go:30",
+		"spawned from:",
+		"main.start",
+		"\t/app/main.go:8",
+	}, "\n"))
+	require.NoError(t, parseErr)
+
+	return err
+}
+
+func TestSetDefaultFormatter(t *testing.T) {
+	err := parsedError(t)
+
+	errors.SetDefaultFormatter(errors.CompactFormatter)
+	assert.Equal(t, "oops at db.go:12 < main.go:30", fmt.Sprintf("%+v", err))
+	assert.Equal(t, "oops", fmt.Sprintf("%v", err), "other verbs are unaffected")
+
+	errors.SetDefaultFormatter(errors.MustFormatter(`{{.Missing}}`))
+	assert.True(t, strings.HasPrefix(fmt.Sprintf("%+v", err), "%!v(errors.Formatter="))
+
+	errors.SetDefaultFormatter(nil)
+	assert.True(t, strings.HasPrefix(fmt.Sprintf("%+v", err), "oops\nmain.load\n"))
+}
+
+func TestFormatterRender(t *testing.T) {
+	t.Run("nil error", func(t *testing.T) {
+		for _, f := range []*errors.Formatter{errors.CompactFormatter, errors.VerboseFormatter, errors.PanicFormatter} {
+			b := strings.Builder{}
+			require.NoError(t, f.Render(&b, nil))
+			assert.Equal(t, "<nil>", b.String())
+		}
+	})
+
+	t.Run("formatting the error in the default formatter", func(t *testing.T) {
+		err := parsedError(t)
+
+		errors.SetDefaultFormatter(errors.MustFormatter(`[{{printf "%+v" .Err}}]`))
+		defer errors.SetDefaultFormatter(nil)
+
+		assert.Equal(t, "[oops\nmain.load\n\t/app/db.go:12\nmain.main\n\t/app/main.go:30\nspawned from:\nmain.start\n\t/app/main.go:8]", fmt.Sprintf("%+v", err))
+	})
+}
@@ -0,0 +1,30 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameFormat(t *testing.T) {
+	line := nextLine()
+	err := errors.New("failure")
+	stack, _ := errors.StackTrace(err)
+	require.NotEmpty(t, stack)
+	f := stack[0]
+
+	assert.Equal(t, fmt.Sprintf("frame-stack_test.go:%d", line), fmt.Sprintf("%s", f))
+	assert.Equal(t, f.File, fmt.Sprintf("%+s", f))
+	assert.True(t, strings.HasSuffix(f.File, "/frame-stack_test.go"))
+	assert.Equal(t, fmt.Sprint(line), fmt.Sprintf("%d", f))
+	assert.Equal(t, "TestFrameFormat", fmt.Sprintf("%n", f))
+	assert.Equal(t, fmt.Sprintf("github.com/rclark/errors_test.TestFrameFormat\n\t%s:%d", f.File, line), fmt.Sprintf("%v", f))
+
+	assert.Equal(t, fmt.Sprintf("%s:%d", f.File, line), fmt.Sprintf("%+s:%d", f, f), "should support pkg/errors style formatting")
+}
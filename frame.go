@@ -1,11 +1,13 @@
 package errors
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"path"
 	"runtime"
 	"strconv"
+	"strings"
 )
 
 // Frame represents a program counter inside a stack trace.
@@ -13,10 +15,10 @@ type Frame struct {
 	pc uintptr
 	fn *runtime.Func
 
-	File     string
-	Line     int
+	File     string `json:"file"`
+	Line     int    `json:"line"`
 	line     string
-	Function string
+	Function string `json:"function"`
 }
 
 func newFrame(c uintptr) Frame {
@@ -41,21 +43,156 @@ func newFrame(c uintptr) Frame {
 	return f
 }
 
-// Format formats the frame according to the fmt.Formatter interface.
+// Path returns the file path to show for the frame, as determined by the
+// function provided to [SetPathRewriter]. If none was provided, it is the same
+// as File.
+func (f Frame) Path() string {
+	if rewrite := pathRewriter.Load(); rewrite != nil {
+		return (*rewrite)(f)
+	}
+
+	return f.File
+}
+
+// lineString returns the line number as a string, which is precomputed for
+// frames captured from the running program.
+func (f Frame) lineString() string {
+	if f.line == "" {
+		return strconv.Itoa(f.Line)
+	}
+
+	return f.line
+}
+
+// Package returns the import path of the package that declares the frame's
+// function, e.g. "github.com/rclark/errors".
+func (f Frame) Package() string {
+	pkg, _ := splitFunction(f.Function)
+	return pkg
+}
+
+// FuncName returns the name of the frame's function without its package, e.g.
+// "(*Group).Go.func1".
+func (f Frame) FuncName() string {
+	_, fn := splitFunction(f.Function)
+	return fn
+}
+
+// IsStdlib reports whether the frame's function is declared in the standard
+// library, which is assumed of any package other than main whose import path
+// does not contain a dot in its first element.
+func (f Frame) IsStdlib() bool {
+	pkg := f.Package()
+	if pkg == "" || pkg == "main" {
+		return false
+	}
+
+	first, _, _ := strings.Cut(pkg, "/")
+	return !strings.Contains(first, ".")
+}
+
+// Receiver returns the receiver type of the frame's function if it is a
+// method, e.g. "*Group" or "Frame". Otherwise, it returns an empty string.
+func (f Frame) Receiver() string {
+	fn := f.FuncName()
+	if strings.HasPrefix(fn, "(") {
+		if end := strings.Index(fn, ")"); end > 0 {
+			return fn[1:end]
+		}
+	}
+
+	recv, rest, ok := strings.Cut(fn, ".")
+	if !ok {
+		return ""
+	}
+
+	// Closures and package initializers look like methods of their function.
+	next, _, _ := strings.Cut(rest, ".")
+	if strings.TrimLeft(next, "0123456789") == "" || isClosureName(next) {
+		return ""
+	}
+
+	return recv
+}
+
+func isClosureName(name string) bool {
+	for _, prefix := range []string{"func", "gowrap", "deferwrap"} {
+		if suffix, ok := strings.CutPrefix(name, prefix); ok && suffix != "" && strings.TrimLeft(suffix, "0123456789") == "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ShortFile returns the base name of the frame's file, e.g. "frame.go".
+func (f Frame) ShortFile() string {
+	return path.Base(f.File)
+}
+
+// Format formats the frame according to the fmt.Formatter interface. The verbs
+// supported by github.com/pkg/errors are also supported, so that formatters
+// written for that package can be reused.
 //
-//   - %s <filename>:<line>
-//   - %v <package>.<function>\n\t<filepath>:<line>
+//   - %s  <filename>:<line>
+//   - %+s <filepath>
+//   - %d  <line>
+//   - %n  <function>
+//   - %v  <package>.<function>\n\t<filepath>:<line>
 func (f Frame) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 's':
-		_, _ = io.WriteString(s, path.Base(f.File)+":"+f.line)
+		if s.Flag('+') {
+			_, _ = io.WriteString(s, f.Path())
+			return
+		}
+
+		_, _ = io.WriteString(s, f.short())
+	case 'd':
+		_, _ = io.WriteString(s, f.lineString())
+	case 'n':
+		_, _ = io.WriteString(s, f.FuncName())
 	case 'v':
-		_, _ = io.WriteString(s, f.Function)
-		_, _ = io.WriteString(s, "\n\t")
-		_, _ = io.WriteString(s, f.File+":"+f.line)
+		_, _ = io.WriteString(s, f.long())
 	}
 }
 
+// short renders the frame as <filename>:<line>.
+func (f Frame) short() string {
+	return f.ShortFile() + ":" + f.lineString()
+}
+
+// long renders the frame as <package>.<function>\n\t<filepath>:<line>.
+func (f Frame) long() string {
+	return f.Function + "\n\t" + f.Path() + ":" + f.lineString()
+}
+
+// MarshalJSON encodes the frame as a JSON object, using [Frame.Path] for its
+// file path.
+func (f Frame) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		File     string `json:"file"`
+		Line     int    `json:"line"`
+		Function string `json:"function"`
+	}{f.Path(), f.Line, f.Function})
+}
+
 func (f Frame) String() string {
 	return fmt.Sprintf("%+v", f)
 }
+
+// splitFunction splits a fully qualified function name into the import path of
+// the package that declares it and the name of the function within that
+// package, e.g. "github.com/rclark/errors" and "(*Group).Go.func1" for
+// "github.com/rclark/errors.(*Group).Go.func1".
+func splitFunction(fn string) (string, string) {
+	slash := strings.LastIndex(fn, "/")
+	dot := strings.Index(fn[slash+1:], ".")
+	if dot < 0 {
+		return "", fn
+	}
+
+	// The linker escapes dots in the last path element, as in "gopkg.in/yaml%2ev3".
+	pkg := strings.ReplaceAll(fn[:slash+1+dot], "%2e", ".")
+	return pkg, fn[slash+1+dot+1:]
+}
@@ -0,0 +1,42 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrameAccessors(t *testing.T) {
+	tests := []struct {
+		function string
+		pkg      string
+		name     string
+		receiver string
+		stdlib   bool
+	}{
+		{"github.com/rclark/errors.New", "github.com/rclark/errors", "New", "", false},
+		{"github.com/rclark/errors.(*Group).Go.func1", "github.com/rclark/errors", "(*Group).Go.func1", "*Group", false},
+		{"github.com/rclark/errors.Frame.Format", "github.com/rclark/errors", "Frame.Format", "Frame", false},
+		{"github.com/rclark/errors_test.TestFrame.func1", "github.com/rclark/errors_test", "TestFrame.func1", "", false},
+		{"github.com/rclark/errors.Go.gowrap1", "github.com/rclark/errors", "Go.gowrap1", "", false},
+		{"github.com/rclark/errors.NewError[...]", "github.com/rclark/errors", "NewError[...]", "", false},
+		{"gopkg.in/yaml%2ev3.Unmarshal", "gopkg.in/yaml.v3", "Unmarshal", "", false},
+		{"main.init.0", "main", "init.0", "", false},
+		{"runtime.goexit", "runtime", "goexit", "", true},
+		{"net/http.HandlerFunc.ServeHTTP", "net/http", "HandlerFunc.ServeHTTP", "HandlerFunc", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.function, func(t *testing.T) {
+			f := errors.Frame{Function: test.function}
+			assert.Equal(t, test.pkg, f.Package(), "package should match")
+			assert.Equal(t, test.name, f.FuncName(), "function name should match")
+			assert.Equal(t, test.receiver, f.Receiver(), "receiver should match")
+			assert.Equal(t, test.stdlib, f.IsStdlib(), "standard library should match")
+		})
+	}
+
+	f := errors.Frame{File: "/path/to/frame.go"}
+	assert.Equal(t, "frame.go", f.ShortFile(), "short file should match")
+}
@@ -0,0 +1,170 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"context"
+	std "errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup(t *testing.T) {
+	t.Run("no errors", func(t *testing.T) {
+		g := errors.Group{}
+		for i := 0; i < 3; i++ {
+			g.Go(func() error { return nil })
+		}
+
+		assert.NoError(t, g.Wait(), "should not return an error")
+	})
+
+	t.Run("collects every error", func(t *testing.T) {
+		a, b := std.New("a"), errors.New("b")
+
+		g := errors.Group{}
+		g.Go(func() error { return a })
+		g.Go(func() error { return nil })
+		g.Go(func() error { return b })
+
+		err := g.Wait()
+		require.Error(t, err)
+		assert.Equal(t, "a\nb", err.Error(), "should join the error messages in order")
+		assert.ErrorIs(t, err, a, "should wrap the first error")
+		assert.Equal(t, b, errors.Unwrap(errors.UnwrapAny(err)[1]), "should wrap the second error")
+		assert.Len(t, errors.UnwrapAny(err), 2, "should only wrap errors")
+	})
+
+	t.Run("records the spawn site", func(t *testing.T) {
+		g := errors.Group{}
+		line := nextLine()
+		g.Go(func() error { return std.New("no stack") })
+
+		err := g.Wait()
+		found := fmt.Sprintf("%+v", err)
+		lines := strings.Split(found, "\n")
+		assert.Equal(t, "no stack", lines[0])
+		assert.Equal(t, "spawned from:", lines[1])
+		assert.Equal(t, "github.com/rclark/errors_test.TestGroup.func3", lines[2], "should show the function that called Go")
+		assert.Contains(t, lines[3], fmt.Sprintf("group-stack_test.go:%d", line), "should show the line that called Go")
+
+		found = fmt.Sprintf("%+s", err)
+		assert.Contains(t, found, fmt.Sprintf("no stack spawned from: [group-stack_test.go:%d", line))
+	})
+
+	t.Run("retains existing stack traces", func(t *testing.T) {
+		g := errors.Group{}
+		g.Go(func() error { return failedLookup(1) })
+
+		err := g.Wait()
+		stack, ok := errors.StackTrace(err)
+		require.True(t, ok, "should have a stack trace")
+		assert.Equal(t, "github.com/rclark/errors_test.failedLookup", stack[0].Function)
+
+		lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+		assert.Equal(t, "failed to find record 1", lines[0])
+		assert.Equal(t, "github.com/rclark/errors_test.failedLookup", lines[1])
+		assert.Contains(t, lines, "spawned from:")
+
+		assert.Equal(t, errors.Fingerprint(failedLookup(2), errors.FingerprintFrames(1)), errors.Fingerprint(err, errors.FingerprintFrames(1)), "should retain the message template")
+	})
+
+	t.Run("recovers panics", func(t *testing.T) {
+		g := errors.Group{}
+		g.Go(panics)
+
+		err := g.Wait()
+		require.Error(t, err)
+		assert.Equal(t, "panic: something terrible", err.Error())
+
+		stack, ok := errors.StackTrace(err)
+		require.True(t, ok, "should have a stack trace")
+		assert.Equal(t, "github.com/rclark/errors_test.panics", stack[0].Function, "should start where the panic occurred")
+	})
+
+	t.Run("recovers panics with errors", func(t *testing.T) {
+		cause := std.New("cause")
+
+		g := errors.Group{}
+		g.Go(func() error { panic(cause) })
+
+		err := g.Wait()
+		assert.EqualError(t, err, "panic: cause")
+		assert.ErrorIs(t, err, cause, "should wrap the panic value")
+	})
+
+	t.Run("cancels context", func(t *testing.T) {
+		g, ctx := errors.NewGroup(context.Background())
+
+		failure := std.New("failure")
+		g.Go(func() error { return failure })
+		g.Go(func() error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		err := g.Wait()
+		assert.ErrorIs(t, err, failure)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, context.Cause(ctx), failure, "should cancel with the first error")
+	})
+
+	t.Run("cancels context on wait", func(t *testing.T) {
+		g, ctx := errors.NewGroup(context.Background())
+		g.Go(func() error { return nil })
+
+		require.NoError(t, g.Wait())
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		var active, peak atomic.Int32
+
+		g := errors.Group{}
+		g.SetLimit(2)
+		for i := 0; i < 10; i++ {
+			g.Go(func() error {
+				n := active.Add(1)
+				defer active.Add(-1)
+
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+
+				time.Sleep(time.Millisecond)
+				return nil
+			})
+		}
+
+		require.NoError(t, g.Wait())
+		assert.LessOrEqual(t, peak.Load(), int32(2), "should not exceed the limit")
+	})
+
+	t.Run("orders errors by start", func(t *testing.T) {
+		release := make(chan struct{})
+
+		g := errors.Group{}
+		g.Go(func() error {
+			<-release
+			return std.New("first")
+		})
+		g.Go(func() error {
+			defer close(release)
+			return std.New("second")
+		})
+
+		err := g.Wait()
+		require.Error(t, err)
+		assert.Equal(t, "first\nsecond", err.Error(), "should order errors by when their goroutines started")
+	})
+}
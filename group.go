@@ -0,0 +1,189 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Group is a collection of goroutines working on subtasks of a common task.
+// Unlike errgroup.Group, it collects the errors returned by every goroutine,
+// recovers panics, and records where each goroutine was started from.
+//
+// A zero Group is valid, has no limit on the number of active goroutines and
+// does not cancel on error.
+type Group struct {
+	origin Stack
+	cancel func(error)
+	wg     sync.WaitGroup
+	sem    chan struct{}
+
+	mu      sync.Mutex
+	started int
+	errs    []groupError
+}
+
+// groupError is an error returned by a goroutine of a [Group], along with the
+// order in which the goroutine was started.
+type groupError struct {
+	seq int
+	err error
+}
+
+// NewGroup returns a new [Group] and an associated context derived from ctx.
+//
+// The derived context is canceled the first time a function passed to
+// [Group.Go] returns a non-nil error or panics, or the first time
+// [Group.Wait] returns, whichever occurs first.
+//
+// If ctx was passed to a function by [GoContext], the stack traces recorded by
+// [Group.Go] include where that function's goroutine was started.
+func NewGroup(ctx context.Context) (*Group, context.Context) {
+	g := &Group{origin: origin(ctx)}
+	ctx, g.cancel = context.WithCancelCause(ctx)
+	return g, ctx
+}
+
+// SetLimit limits the number of active goroutines in the group to at most n. A
+// negative value indicates no limit. It must not be called while any
+// goroutines in the group are active.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+
+	g.sem = make(chan struct{}, n)
+}
+
+// Go calls the given function in a new goroutine. It blocks until the new
+// goroutine can be added without the number of active goroutines in the group
+// exceeding the configured limit.
+//
+// If the function returns an error or panics, the resulting error records the
+// stack trace of the call to Go, which is included when it is formatted.
+func (g *Group) Go(fn func() error) {
+	site := newSpawnSite(g.origin)
+
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.mu.Lock()
+	seq := g.started
+	g.started++
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+
+		err := run(fn)
+		if err == nil {
+			return
+		}
+
+		err = withOrigin(err, site)
+
+		g.mu.Lock()
+		g.errs = append(g.errs, groupError{seq: seq, err: err})
+		g.mu.Unlock()
+
+		if g.cancel != nil {
+			g.cancel(err)
+		}
+	}()
+}
+
+func (g *Group) done() {
+	if g.sem != nil {
+		<-g.sem
+	}
+
+	g.wg.Done()
+}
+
+// Wait blocks until all function calls from the [Group.Go] method have
+// returned, then returns an error that wraps every error they returned, in the
+// order that they were started. Wait returns nil if none of them failed.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+
+	if g.cancel != nil {
+		g.cancel(nil)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	sort.Slice(g.errs, func(i, j int) bool {
+		return g.errs[i].seq < g.errs[j].seq
+	})
+
+	errs := make([]error, len(g.errs))
+	for i, e := range g.errs {
+		errs[i] = e.err
+	}
+
+	return join(errs)
+}
+
+// run calls fn, converting a panic into an error with a stack trace to the
+// point where the panic occurred.
+func run(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = newPanicError(r)
+		}
+	}()
+
+	return fn()
+}
+
+func newPanicError(r any) error {
+	e := newError(fmt.Sprintf("panic: %v", r), options{})
+	if err, ok := r.(error); ok {
+		e.err = err
+	}
+
+	// Start the stack trace where the panic occurred, rather than in the
+	// deferred function that recovered from it.
+	for i, f := range e.stack {
+		if f.Function == "runtime.gopanic" {
+			e.stack = e.stack[i+1:]
+			break
+		}
+	}
+
+	for len(e.stack) > 0 && strings.HasPrefix(e.stack[0].Function, "runtime.") {
+		e.stack = e.stack[1:]
+	}
+
+	return e
+}
+
+// withOrigin wraps err with the place where the goroutine that produced it was
+// started. Any stack trace that err already has is retained. If err was already
+// returned from another goroutine, it is returned unchanged, since the innermost
+// spawn site is the most specific.
+func withOrigin(err error, site spawnSite) error {
+	if site.stack.IsZero() {
+		return err
+	}
+
+	if _, ok := SpawnedFrom(err); ok {
+		return err
+	}
+
+	e := Error{message: err.Error(), template: err.Error(), err: err, origin: site.stack, spawner: site.goroutine}
+
+	var inner Error
+	if As(err, &inner) {
+		e.template = inner.template
+	}
+
+	e.stack, _ = StackTrace(err)
+	return e
+}
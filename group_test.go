@@ -0,0 +1,36 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rclark/errors"
+)
+
+func panics() error {
+	panic("something terrible")
+}
+
+func ExampleGroup() {
+	g, ctx := errors.NewGroup(context.Background())
+
+	for _, name := range []string{"a", "b", "c"} {
+		g.Go(func() error {
+			if name == "a" {
+				return nil
+			}
+
+			select {
+			case <-ctx.Done():
+			default:
+			}
+
+			return errors.Errorf("failed to process %s", name)
+		})
+	}
+
+	err := g.Wait()
+	fmt.Println(len(errors.UnwrapAny(err)))
+
+	// Output: 2
+}
@@ -0,0 +1,128 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type constructor func(opts ...errors.CaptureOption) error
+
+// constructors create errors with each function that accepts capture options.
+var constructors = map[string]constructor{
+	"New": func(opts ...errors.CaptureOption) error {
+		return errors.New("oops", optionsOf[errors.StackOption](opts)...)
+	},
+	"Errorf": func(opts ...errors.CaptureOption) error {
+		args := append([]any{1}, optionsOf[any](opts)...)
+		return errors.Errorf("oops %d", args...)
+	},
+	"WithStack": func(opts ...errors.CaptureOption) error {
+		return errors.WithStack(fmt.Errorf("oops"), optionsOf[errors.StackOption](opts)...)
+	},
+	"NewError": func(opts ...errors.CaptureOption) error {
+		return errors.NewError[errors.MissingError]("oops", optionsOf[errors.UserFacingOption](opts)...)
+	},
+	"NewUserFacingError": func(opts ...errors.CaptureOption) error {
+		return errors.NewUserFacingError("oops", optionsOf[errors.UserFacingOption](opts)...)
+	},
+}
+
+// optionsOf converts capture options to the option type of a constructor, as
+// described by CaptureOption.
+func optionsOf[T any](opts []errors.CaptureOption) []T {
+	converted := make([]T, len(opts))
+	for i, opt := range opts {
+		converted[i] = any(opt).(T)
+	}
+	return converted
+}
+
+func topFunction(t *testing.T, err error) string {
+	t.Helper()
+
+	st, ok := errors.StackTrace(err)
+	require.True(t, ok)
+	return st[0].Function
+}
+
+//go:noinline
+func wrapper(c constructor) error {
+	return c(errors.CallerOf(wrapper))
+}
+
+//go:noinline
+func registeredWrapper(c constructor) error {
+	return c(errors.SkipFrames(1))
+}
+
+func TestStackOptions(t *testing.T) {
+	const caller = "github.com/rclark/errors_test.TestStackOptions.func1"
+
+	for name, c := range constructors {
+		t.Run(name, func(t *testing.T) {
+			assert.Contains(t, topFunction(t, c()), "errors_test.init", "stack trace starts in the constructor")
+			assert.Equal(t, caller, topFunction(t, c(errors.SkipFrames(1))))
+			assert.Equal(t, caller, topFunction(t, c(errors.CallerOf(c))))
+			assert.Equal(t, caller, topFunction(t, wrapper(c)), "frames up to the function are skipped")
+			assert.Contains(t, topFunction(t, c(errors.CallerOf(t.Run))), "errors_test.init", "functions not on the stack are ignored")
+		})
+	}
+}
+
+func TestRegisterHelper(t *testing.T) {
+	const caller = "github.com/rclark/errors_test.TestRegisterHelper.func1"
+
+	errors.RegisterHelper(registeredWrapper, "not a function", nil)
+
+	for name, c := range constructors {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, caller, topFunction(t, registeredWrapper(c)))
+		})
+	}
+}
+
+//go:noinline
+func markedHelper() error {
+	errors.Helper()
+	return errors.NewError[errors.MissingError]("not found")
+}
+
+//go:noinline
+func nestedMarkedHelper() error {
+	errors.Helper()
+	return errors.Errorf("wrapped: %w", markedHelper())
+}
+
+func TestHelper(t *testing.T) {
+	const caller = "github.com/rclark/errors_test.TestHelper"
+
+	assert.Equal(t, caller, topFunction(t, markedHelper()))
+	assert.Equal(t, caller, topFunction(t, nestedMarkedHelper()))
+
+	t.Run("concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make([]error, 16)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = func() error {
+					errors.Helper()
+					return errors.New("oops")
+				}()
+			}(i)
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			assert.Equal(t, "github.com/rclark/errors_test.TestHelper.func1.1", topFunction(t, err))
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package errors
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rclark/errors/internal/funcname"
+)
+
+// SkipFrames skips n more frames of the stack trace captured for a new error,
+// so that it starts further up the stack than the function that created the
+// error. This is useful for functions that create errors on behalf of their
+// callers.
+//
+//	func notFound(what string) error {
+//		return errors.New(what+" not found", errors.SkipFrames(1))
+//	}
+func SkipFrames(n int) CaptureOption {
+	return func(o *options) {
+		o.frames += n
+	}
+}
+
+// CallerOf starts the stack trace captured for a new error at the caller of
+// the provided function, skipping every frame up to and including fn. If fn is
+// not on the stack, the option has no effect.
+//
+//	func notFound(what string) error {
+//		return errors.New(what+" not found", errors.CallerOf(notFound))
+//	}
+func CallerOf(fn any) CaptureOption {
+	name := funcname.Of(fn)
+	return func(o *options) {
+		o.callerOf = name
+	}
+}
+
+var (
+	helpers     sync.Map
+	helperCount atomic.Int64
+)
+
+// Helper marks the calling function as a helper that creates errors on behalf
+// of its callers, like testing.T.Helper. The stack trace captured for a new
+// error created through it, by [New], [Errorf], [WithStack], [NewError] or
+// [NewUserFacingError], starts at its caller instead. It is safe for
+// concurrent use.
+//
+//	func notFound(what string) error {
+//		errors.Helper()
+//		return errors.New(what + " not found")
+//	}
+func Helper() {
+	pc, _, _, ok := runtime.Caller(1)
+	if !ok {
+		return
+	}
+
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return
+	}
+
+	name := fn.Name()
+	if _, ok := helpers.Load(name); !ok {
+		registerHelper(name)
+	}
+}
+
+// RegisterHelper marks functions as helpers that create errors on behalf of
+// their callers, like calling [Helper] from within each of them. The frames of
+// helpers at the top of a stack trace captured for a new error are skipped, so
+// that it starts at the first caller that is not a helper. It is safe for
+// concurrent use.
+//
+//	func init() {
+//		errors.RegisterHelper(notFound)
+//	}
+func RegisterHelper(fns ...any) {
+	for _, fn := range fns {
+		registerHelper(funcname.Of(fn))
+	}
+}
+
+func registerHelper(name string) {
+	if name == "" {
+		return
+	}
+
+	if _, loaded := helpers.LoadOrStore(name, struct{}{}); !loaded {
+		helperCount.Add(1)
+	}
+}
+
+func isHelper(f Frame) bool {
+	_, ok := helpers.Load(f.Function)
+	return ok
+}
+
+// ownPackage is the prefix of the names of functions declared in this package.
+var ownPackage = reflect.TypeOf(Error{}).PkgPath() + "."
+
+// isOwnPackage reports whether the frame's function is declared in this
+// package.
+func isOwnPackage(f Frame) bool {
+	return strings.HasPrefix(f.Function, ownPackage)
+}
+
+// trimStack removes the frames from the start of a captured stack trace that
+// were requested to be skipped via [CallerOf] and [RegisterHelper].
+func trimStack(st Stack, o options) Stack {
+	if o.callerOf != "" {
+		for i, f := range st {
+			if f.Function == o.callerOf {
+				st = st[i+1:]
+				break
+			}
+		}
+	}
+
+	if helperCount.Load() > 0 {
+		for len(st) > 0 && isHelper(st[0]) {
+			st = st[1:]
+		}
+	}
+
+	return st
+}
@@ -0,0 +1,22 @@
+package errors_test
+
+import (
+	std "errors"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionTypes(t *testing.T) {
+	cause := std.New("cause")
+
+	_, ok := any(errors.FromError(cause)).(errors.StackOption)
+	assert.False(t, ok, "user-facing options are not stack options")
+
+	_, ok = any(errors.Overwrite()).(errors.UserFacingOption)
+	assert.False(t, ok, "stack options are not user-facing options")
+
+	err := errors.Errorf("oops: %T", errors.FromError(cause))
+	assert.Equal(t, "oops: errors.userFacingOption", err.Error(), "Errorf formats user-facing options")
+}
@@ -0,0 +1,104 @@
+package errors
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+const stackCacheShards = 16
+
+// stacks interns the stacks captured for errors, so that errors created at the
+// same call site share one [Stack] rather than each resolving its frames.
+var stacks = newStackCache(4096)
+
+// SetStackCacheSize sets the maximum number of distinct stack traces that are
+// kept for reuse by errors created at the same call site. When the cache is
+// full, stack traces are evicted to make room for new ones. Providing zero
+// disables the cache. The default size is 4096.
+func SetStackCacheSize(n int) {
+	stacks.resize(n)
+}
+
+// stackCache is a concurrency-safe, bounded cache of stacks keyed by their
+// program counters. It is sharded to reduce lock contention.
+type stackCache struct {
+	limit  atomic.Int64
+	shards [stackCacheShards]stackCacheShard
+}
+
+type stackCacheShard struct {
+	mu      sync.RWMutex
+	entries map[uint64]cachedStack
+}
+
+type cachedStack struct {
+	pcs   []uintptr
+	stack Stack
+}
+
+func newStackCache(size int) *stackCache {
+	c := &stackCache{}
+	for i := range c.shards {
+		c.shards[i].entries = map[uint64]cachedStack{}
+	}
+
+	c.resize(size)
+	return c
+}
+
+// resize sets the total number of stacks that the cache holds. Shards that
+// hold more than their share are trimmed as new stacks are added to them.
+func (c *stackCache) resize(size int) {
+	per := (size + stackCacheShards - 1) / stackCacheShards
+	c.limit.Store(int64(max(per, 0)))
+}
+
+// intern returns the stack for the program counters, resolving its frames
+// only if they are not already in the cache. The returned stack is shared and
+// has no spare capacity, so that appending to it copies it.
+func (c *stackCache) intern(pcs []uintptr) Stack {
+	limit := int(c.limit.Load())
+	if limit == 0 || len(pcs) == 0 {
+		return newStack(pcs)
+	}
+
+	key := hashPCs(pcs)
+	shard := &c.shards[key%stackCacheShards]
+
+	shard.mu.RLock()
+	cached, ok := shard.entries[key]
+	shard.mu.RUnlock()
+
+	if ok && slices.Equal(cached.pcs, pcs) {
+		return cached.stack
+	}
+
+	st := newStack(pcs)
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	for k := range shard.entries {
+		if len(shard.entries) < limit {
+			break
+		}
+		delete(shard.entries, k)
+	}
+
+	shard.entries[key] = cachedStack{pcs: slices.Clone(pcs), stack: st}
+	return st
+}
+
+// hashPCs hashes program counters with FNV-1a.
+func hashPCs(pcs []uintptr) uint64 {
+	h := uint64(14695981039346656037)
+	for _, pc := range pcs {
+		for i := 0; i < 8; i++ {
+			h ^= uint64(pc>>(8*i)) & 0xff
+			h *= 1099511628211
+		}
+	}
+
+	return h
+}
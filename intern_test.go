@@ -0,0 +1,139 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStacks(n int) []errors.Stack {
+	stacks := make([]errors.Stack, n)
+	for i := range stacks {
+		stacks[i], _ = errors.StackTrace(errors.New("oops"))
+	}
+
+	return stacks
+}
+
+func TestStackInterning(t *testing.T) {
+	t.Run("same call site", func(t *testing.T) {
+		stacks := newStacks(2)
+		require.NotEmpty(t, stacks[0])
+		assert.Equal(t, stacks[0], stacks[1])
+	})
+
+	t.Run("different call sites", func(t *testing.T) {
+		a, _ := errors.StackTrace(errors.New("oops"))
+		b, _ := errors.StackTrace(errors.New("oops"))
+		assert.NotSame(t, &a[0], &b[0])
+		assert.NotEqual(t, a[0].Line, b[0].Line)
+	})
+
+	t.Run("append copies", func(t *testing.T) {
+		stacks := newStacks(2)
+		extended := append(stacks[0], errors.Frame{Function: "main.main"})
+		extended[0] = errors.Frame{}
+
+		assert.NotEmpty(t, stacks[1][0].Function)
+		assert.Len(t, stacks[1], len(stacks[0]))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		errors.SetStackCacheSize(0)
+		defer errors.SetStackCacheSize(4096)
+
+		stacks := newStacks(2)
+		assert.NotSame(t, &stacks[0][0], &stacks[1][0])
+		assert.Equal(t, stacks[0], stacks[1])
+	})
+
+	t.Run("small cache", func(t *testing.T) {
+		errors.SetStackCacheSize(1)
+		defer errors.SetStackCacheSize(4096)
+
+		for i := 0; i < 100; i++ {
+			a, _ := errors.StackTrace(errors.New("oops"))
+			b, _ := errors.StackTrace(errors.New("oops"))
+			assert.NotEqual(t, a[0].Line, b[0].Line)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+		results := make([][]errors.Stack, 8)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i] = newStacks(100)
+			}(i)
+		}
+		wg.Wait()
+
+		for _, stacks := range results {
+			for _, st := range stacks {
+				assert.Equal(t, results[0][0][0].Line, st[0].Line)
+			}
+		}
+	})
+
+	t.Run("returns copies", func(t *testing.T) {
+		stacks := newStacks(1)
+		require.NotEmpty(t, stacks[0])
+		stacks[0][0] = errors.Frame{}
+
+		again := newStacks(1)
+		assert.NotEmpty(t, again[0][0].Function)
+
+		var e errors.Error
+		require.True(t, errors.As(errors.New("oops"), &e))
+		st := e.StackTrace()
+		st[0].Line = 0
+		assert.NotZero(t, e.StackTrace()[0].Line)
+	})
+}
+
+func BenchmarkNew(b *testing.B) {
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = errors.New("oops")
+		}
+	})
+
+	b.Run("uncached", func(b *testing.B) {
+		errors.SetStackCacheSize(0)
+		defer errors.SetStackCacheSize(4096)
+
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = errors.New("oops")
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = errors.New("oops")
+			}
+		})
+	})
+
+	b.Run("parallel uncached", func(b *testing.B) {
+		errors.SetStackCacheSize(0)
+		defer errors.SetStackCacheSize(4096)
+
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = errors.New("oops")
+			}
+		})
+	})
+}
@@ -0,0 +1,14 @@
+// Package ansi defines the escape codes used to color terminal output, shared
+// by the errors package and the errfmt command.
+package ansi
+
+// Escape codes that start a style or color, and Reset, which ends them.
+const (
+	Bold    = "\x1b[1m"
+	Dim     = "\x1b[2m"
+	Red     = "\x1b[31m"
+	Yellow  = "\x1b[33m"
+	Magenta = "\x1b[35m"
+	Cyan    = "\x1b[36m"
+	Reset   = "\x1b[0m"
+)
@@ -0,0 +1,26 @@
+// Package funcname resolves function values to the names that the runtime
+// reports for them in stack frames.
+package funcname
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Of returns the fully qualified name of a function value, or an empty string
+// if fn is not a function.
+func Of(fn any) string {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return ""
+	}
+
+	f := runtime.FuncForPC(v.Pointer())
+	if f == nil {
+		return ""
+	}
+
+	// Method values, such as t.Method, are implemented by a wrapper function.
+	return strings.TrimSuffix(f.Name(), "-fm")
+}
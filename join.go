@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+)
+
+// joinError is an error that wraps many errors, and includes each of their
+// stack traces when formatted.
+type joinError struct {
+	errs []error
+}
+
+func join(errs []error) error {
+	e := joinError{errs: make([]error, 0, len(errs))}
+	for _, err := range errs {
+		if err != nil {
+			e.errs = append(e.errs, err)
+		}
+	}
+
+	if len(e.errs) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+func (e joinError) Error() string {
+	return Join(e.errs...).Error()
+}
+
+func (e joinError) Unwrap() []error {
+	return e.errs
+}
+
+// Format formats the error according to the fmt.Formatter interface. With the
+// + flag, each of the wrapped errors is formatted with the same verb and flag,
+// separated by blank lines.
+func (e joinError) Format(s fmt.State, verb rune) {
+	if !s.Flag('+') {
+		_, _ = io.WriteString(s, e.Error())
+		return
+	}
+
+	for i, err := range e.errs {
+		if i > 0 {
+			_, _ = io.WriteString(s, "\n\n")
+		}
+
+		fmt.Fprintf(s, "%+"+string(verb), err)
+	}
+}
@@ -0,0 +1,99 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"context"
+	std "errors"
+	"sync"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		m := &fakeMetrics{}
+		errors.SetMetrics(m)
+		defer errors.SetMetrics(nil)
+
+		_ = errors.New("new")
+		_ = errors.Errorf("errorf")
+		_ = errors.NewUserFacingError("user facing")
+		_ = errors.NewError[errors.BadInputError]("bad input")
+		_ = errors.Errorf("wrapped: %w", errors.NewError[errors.MissingError]("missing"))
+
+		assert.Equal(t, []errors.MetricLabels{
+			{Category: ""},
+			{Category: ""},
+			{Category: ""},
+			{Category: "BadInputError"},
+			{Category: "MissingError"},
+		}, m.created, "should count each created error by category")
+		assert.Empty(t, m.reported, "should not count any reported errors")
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		m := &fakeMetrics{}
+		errors.SetMetrics(m)
+		defer errors.SetMetrics(nil)
+
+		err := errors.NewError[errors.BadInputError]("bad input")
+		err = errors.Errorf("ctx: %w", err)
+		err = errors.Errorf("ctx: %w", err, errors.Overwrite())
+		_ = errors.Errorf("ctx: %w", std.New("no stack"))
+
+		assert.Equal(t, []errors.MetricLabels{{Category: "BadInputError"}}, m.created, "should not count wrapping an error as creating one")
+	})
+
+	t.Run("reported", func(t *testing.T) {
+		m := &fakeMetrics{}
+		errors.SetMetrics(m)
+		defer errors.SetMetrics(nil)
+
+		err := errors.NewError[errors.ConflictError]("conflict")
+		r := errors.NewReporter(errors.ToSinks(&errors.MemorySink{}))
+		require.NoError(t, r.Report(context.Background(), err))
+
+		assert.Equal(t, []errors.MetricLabels{{Category: "ConflictError"}}, m.reported, "should count reported errors")
+	})
+
+	t.Run("function label", func(t *testing.T) {
+		m := &fakeMetrics{}
+		errors.SetMetrics(m, errors.LabelFunction())
+		defer errors.SetMetrics(nil)
+
+		_ = failedLookup(1)
+		require.Len(t, m.created, 1)
+		assert.Equal(t, "github.com/rclark/errors_test.failedLookup", m.created[0].Function, "should label the top in-app function")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		m := &fakeMetrics{}
+		errors.SetMetrics(m)
+		errors.SetMetrics(nil)
+
+		_ = errors.New("new")
+		assert.Empty(t, m.created, "should not count errors once disabled")
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		m := &fakeMetrics{}
+		errors.SetMetrics(m)
+		defer errors.SetMetrics(nil)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = errors.NewError[errors.TimeoutError]("timeout")
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, m.created, 50, "should count every error")
+	})
+}
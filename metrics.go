@@ -0,0 +1,165 @@
+package errors
+
+import (
+	"expvar"
+	"sync/atomic"
+)
+
+// MetricLabels describe an error that is counted by [Metrics].
+type MetricLabels struct {
+	// Category is the error's [Category], or empty if it has none.
+	Category string
+
+	// Function is the fully qualified name of the top function in the error's
+	// [Stack] that belongs to the main module. It is only set when metrics are
+	// configured with [LabelFunction].
+	Function string
+}
+
+// Metrics counts errors as they are created and reported. Implementations must
+// be safe for concurrent use. See [SetMetrics].
+type Metrics interface {
+	// ErrorCreated is called when an error is created by [New], [NewError],
+	// [NewUserFacingError] or [Errorf], unless it wraps an existing error.
+	ErrorCreated(labels MetricLabels)
+
+	// ErrorReported is called when a [Reporter] delivers an error to its sinks.
+	ErrorReported(labels MetricLabels)
+}
+
+type metricsOptions struct {
+	m          Metrics
+	byFunction bool
+}
+
+// MetricsOption configures the collection of [Metrics].
+type MetricsOption func(*metricsOptions)
+
+// LabelFunction adds the top in-app function of each error's [Stack] to its
+// [MetricLabels].
+func LabelFunction() MetricsOption {
+	return func(o *metricsOptions) {
+		o.byFunction = true
+	}
+}
+
+var metrics atomic.Pointer[metricsOptions]
+
+// SetMetrics sets the [Metrics] that errors will be counted by. Providing nil
+// disables the collection of metrics, which is the default.
+func SetMetrics(m Metrics, opts ...MetricsOption) {
+	if m == nil {
+		metrics.Store(nil)
+		return
+	}
+
+	o := metricsOptions{m: m}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	metrics.Store(&o)
+}
+
+func (o *metricsOptions) labels(category string, stack func() Stack) MetricLabels {
+	labels := MetricLabels{Category: category}
+	if o.byFunction {
+		labels.Function = topFunction(stack())
+	}
+
+	return labels
+}
+
+// recordCreated counts the creation of err. If category is empty, it is
+// looked up from the error.
+func recordCreated(err error, category string) {
+	o := metrics.Load()
+	if o == nil {
+		return
+	}
+
+	if category == "" {
+		category, _ = Category(err)
+	}
+
+	o.m.ErrorCreated(o.labels(category, func() Stack {
+		st, _ := StackTrace(err)
+		return st
+	}))
+}
+
+func recordReported(r Report) {
+	o := metrics.Load()
+	if o == nil {
+		return
+	}
+
+	o.m.ErrorReported(o.labels(r.Category, func() Stack {
+		return r.Stack
+	}))
+}
+
+// topFunction returns the function of the first frame in the stack that belongs
+// to the main module, or of the first frame if none do.
+func topFunction(st Stack) string {
+	if st.IsZero() {
+		return ""
+	}
+
+	module := mainModule()
+	for _, f := range st {
+		if inModule(f, module) {
+			return f.Function
+		}
+	}
+
+	return st[0].Function
+}
+
+// ExpvarMetrics is an implementation of [Metrics] that publishes counters via
+// the expvar package.
+type ExpvarMetrics struct {
+	created  *expvar.Map
+	reported *expvar.Map
+}
+
+// NewExpvarMetrics creates an [ExpvarMetrics], publishing a map under the
+// provided name. The map contains "created" and "reported" maps of counters,
+// keyed by category, or by category and function separated by a space when
+// metrics are configured with [LabelFunction]. Like [expvar.Publish], it
+// panics if the name is already in use.
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	m := &ExpvarMetrics{
+		created:  new(expvar.Map),
+		reported: new(expvar.Map),
+	}
+
+	root := expvar.NewMap(name)
+	root.Set("created", m.created)
+	root.Set("reported", m.reported)
+
+	return m
+}
+
+// ErrorCreated increments the counter of created errors.
+func (m *ExpvarMetrics) ErrorCreated(labels MetricLabels) {
+	m.created.Add(expvarKey(labels), 1)
+}
+
+// ErrorReported increments the counter of reported errors.
+func (m *ExpvarMetrics) ErrorReported(labels MetricLabels) {
+	m.reported.Add(expvarKey(labels), 1)
+}
+
+func expvarKey(labels MetricLabels) string {
+	key := labels.Category
+	if key == "" {
+		key = "uncategorized"
+	}
+
+	if labels.Function != "" {
+		key += " " + labels.Function
+	}
+
+	return key
+}
@@ -0,0 +1,55 @@
+package errors_test
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"sync"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMetrics struct {
+	mu       sync.Mutex
+	created  []errors.MetricLabels
+	reported []errors.MetricLabels
+}
+
+func (m *fakeMetrics) ErrorCreated(labels errors.MetricLabels) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.created = append(m.created, labels)
+}
+
+func (m *fakeMetrics) ErrorReported(labels errors.MetricLabels) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reported = append(m.reported, labels)
+}
+
+// expvarMetrics is published once, since expvar names cannot be reused.
+var expvarMetrics = errors.NewExpvarMetrics("errors_test")
+
+func TestExpvarMetrics(t *testing.T) {
+	published := expvar.Get("errors_test").(*expvar.Map)
+	published.Get("created").(*expvar.Map).Init()
+	published.Get("reported").(*expvar.Map).Init()
+
+	errors.SetMetrics(expvarMetrics)
+	defer errors.SetMetrics(nil)
+
+	_ = errors.New("new")
+	err := errors.NewError[errors.BadInputError]("bad input")
+	r := errors.NewReporter(errors.ToSinks(&errors.MemorySink{}))
+	require.NoError(t, r.Report(context.Background(), err))
+
+	var found map[string]map[string]int
+	require.NoError(t, json.Unmarshal([]byte(published.String()), &found))
+	assert.Equal(t, map[string]map[string]int{
+		"created":  {"uncategorized": 1, "BadInputError": 1},
+		"reported": {"BadInputError": 1},
+	}, found)
+}
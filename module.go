@@ -0,0 +1,39 @@
+package errors
+
+import (
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// mainModule returns the path of the main module of the running binary, or an
+// empty string if it could not be determined.
+var mainModule = sync.OnceValue(func() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Path
+	}
+
+	return ""
+})
+
+// mainPackage returns the import path of package main of the running binary,
+// or an empty string if it could not be determined.
+var mainPackage = sync.OnceValue(func() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Path
+	}
+
+	return ""
+})
+
+// inModule reports whether the function of the frame belongs to a package
+// inside of the given module. External test packages and package main are
+// considered to be part of the module.
+func inModule(f Frame, module string) bool {
+	if module == "" {
+		return false
+	}
+
+	pkg := strings.TrimSuffix(f.Package(), "_test")
+	return pkg == "main" || pkg == module || strings.HasPrefix(pkg, module+"/")
+}
@@ -0,0 +1,103 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"context"
+	std "errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		err := <-errors.Go(func() error { return nil })
+		assert.NoError(t, err)
+	})
+
+	t.Run("records the spawn site", func(t *testing.T) {
+		line := nextLine()
+		err := <-errors.Go(func() error { return failedLookup(1) })
+		require.Error(t, err)
+
+		stack, ok := errors.StackTrace(err)
+		require.True(t, ok, "should retain the stack trace")
+		assert.Equal(t, "github.com/rclark/errors_test.failedLookup", stack[0].Function)
+
+		origin, ok := errors.SpawnedFrom(err)
+		require.True(t, ok, "should have a spawn site")
+		assert.Equal(t, "github.com/rclark/errors_test.TestGo.func2", origin[0].Function)
+		assert.Equal(t, line, origin[0].Line)
+
+		found := fmt.Sprintf("%+v", err)
+		expect := fmt.Sprintf("spawned from:\ngithub.com/rclark/errors_test.TestGo.func2\n\t%s:%d", origin[0].File, line)
+		assert.Contains(t, found, expect, "should render the spawn site")
+	})
+
+	t.Run("recovers panics", func(t *testing.T) {
+		err := <-errors.Go(panics)
+		assert.EqualError(t, err, "panic: something terrible")
+
+		_, ok := errors.SpawnedFrom(err)
+		assert.True(t, ok, "should have a spawn site")
+	})
+}
+
+func TestGoContext(t *testing.T) {
+	t.Run("nested goroutines", func(t *testing.T) {
+		outer := nextLine()
+		err := <-errors.GoContext(context.Background(), func(ctx context.Context) error {
+			return <-errors.GoContext(ctx, func(ctx context.Context) error {
+				return std.New("failure")
+			})
+		})
+		require.EqualError(t, err, "failure")
+
+		origin, ok := errors.SpawnedFrom(err)
+		require.True(t, ok, "should have a spawn site")
+
+		functions := []string{}
+		for _, f := range origin {
+			functions = append(functions, f.Function)
+		}
+
+		assert.Equal(t, "github.com/rclark/errors_test.TestGoContext.func1.1", functions[0], "should start at the inner spawn site")
+		assert.Contains(t, functions, "github.com/rclark/errors_test.TestGoContext.func1", "should include the outer spawn site")
+
+		last := origin[len(origin)-1]
+		assert.True(t, strings.HasSuffix(last.Function, "tRunner") || strings.HasSuffix(last.Function, "goexit"))
+
+		found := false
+		for _, f := range origin {
+			if f.Function == "github.com/rclark/errors_test.TestGoContext.func1" && f.Line == outer {
+				found = true
+			}
+		}
+		assert.True(t, found, "should include the line of the outer spawn site")
+	})
+
+	t.Run("groups", func(t *testing.T) {
+		err := <-errors.GoContext(context.Background(), func(ctx context.Context) error {
+			g, _ := errors.NewGroup(ctx)
+			g.Go(func() error { return std.New("failure") })
+			return g.Wait()
+		})
+		require.EqualError(t, err, "failure")
+
+		origin, ok := errors.SpawnedFrom(err)
+		require.True(t, ok, "should have a spawn site")
+
+		functions := []string{}
+		for _, f := range origin {
+			functions = append(functions, f.Function)
+		}
+
+		assert.Equal(t, "github.com/rclark/errors_test.TestGoContext.func2.1", functions[0], "should start at the group's spawn site")
+		assert.Contains(t, functions, "github.com/rclark/errors_test.TestGoContext.func2", "should include the outer spawn site")
+	})
+}
@@ -0,0 +1,120 @@
+package errors
+
+import (
+	"context"
+	"slices"
+)
+
+type originKey struct{}
+
+// origin returns the stack trace of the place that started the goroutine that
+// the context was passed to by [GoContext], if any.
+func origin(ctx context.Context) Stack {
+	st, _ := ctx.Value(originKey{}).(Stack)
+	return st
+}
+
+// Go calls the given function in a new goroutine, and returns a channel that
+// will receive its result once it returns.
+//
+// If the function returns an error or panics, the resulting error records the
+// stack trace of the call to Go, which is included when it is formatted.
+func Go(fn func() error) <-chan error {
+	return spawn(newSpawnSite(nil), fn)
+}
+
+// GoContext calls the given function in a new goroutine, and returns a channel
+// that will receive its result once it returns. Like [Go], errors that the
+// function returns record the stack trace of the call to GoContext.
+//
+// The context passed to the function carries that stack trace, so that calls to
+// GoContext made by the function with that context record the complete chain
+// of goroutines that led to them.
+func GoContext(ctx context.Context, fn func(ctx context.Context) error) <-chan error {
+	site := newSpawnSite(origin(ctx))
+	ctx = context.WithValue(ctx, originKey{}, site.stack)
+
+	return spawn(site, func() error {
+		return fn(ctx)
+	})
+}
+
+// spawnSite is the place that started a goroutine.
+type spawnSite struct {
+	stack     Stack
+	goroutine uint64
+}
+
+// newSpawnSite records the stack trace of the caller of the function that calls
+// it, followed by the stack trace of the place that started the calling
+// goroutine, if any. The ID of the calling goroutine is recorded when
+// [SetRecordCreation] is enabled. Nothing is recorded when the [CapturePolicy]
+// does not capture stack traces.
+func newSpawnSite(parent Stack) spawnSite {
+	if !shouldCapture("") {
+		return spawnSite{}
+	}
+
+	site := spawnSite{stack: append(callers(3), parent...)}
+	if recordCreation.Load() {
+		site.goroutine = goroutineID()
+	}
+
+	return site
+}
+
+func spawn(site spawnSite, fn func() error) <-chan error {
+	result := make(chan error, 1)
+
+	go func() {
+		defer close(result)
+
+		err := run(fn)
+		if err != nil {
+			err = withOrigin(err, site)
+		}
+
+		result <- err
+	}()
+
+	return result
+}
+
+// SpawnedFrom returns the stack trace of the place that started the goroutine
+// that err was returned from, if it was returned from a goroutine started by
+// [Go], [GoContext] or [Group.Go]. If none was found, the returned bool will
+// be false. The returned [Stack] is a copy.
+func SpawnedFrom(err error) (Stack, bool) {
+	e, ok := findOrigin(err)
+	return slices.Clone(e.origin), ok
+}
+
+// findOrigin returns the first error in the tree that records where its
+// goroutine was started.
+func findOrigin(err error) (Error, bool) {
+	if e, ok := err.(Error); ok && len(e.origin) > 0 {
+		return e, true
+	}
+
+	for _, child := range children(err) {
+		if e, ok := findOrigin(child); ok {
+			return e, true
+		}
+	}
+
+	return Error{}, false
+}
+
+// children returns the errors directly wrapped by err.
+func children(err error) []error {
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		return e.Unwrap()
+	case interface{ Unwrap() error }:
+		if child := e.Unwrap(); child != nil {
+			return []error{child}
+		}
+	}
+
+	return nil
+}
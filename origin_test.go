@@ -0,0 +1,13 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpawnedFrom(t *testing.T) {
+	_, ok := errors.SpawnedFrom(errors.New("failure"))
+	assert.False(t, ok, "should not have a spawn site")
+}
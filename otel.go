@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// OpenTelemetry semantic convention attribute keys for exceptions.
+const (
+	ExceptionTypeKey       = "exception.type"
+	ExceptionMessageKey    = "exception.message"
+	ExceptionStacktraceKey = "exception.stacktrace"
+	ThreadIDKey            = "thread.id"
+)
+
+// Attribute is a key/value pair that describes an error to a tracer.
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// ExceptionAttributes returns the OpenTelemetry semantic convention attributes
+// that describe err. The exception type is the error's [Category] if it has
+// one, or its Go type otherwise. The stack trace is included only if err has
+// a [Stack], and is rendered in the layout that the Go runtime uses for
+// goroutine stack traces. The ID of the goroutine that created the error is
+// included as the thread ID if it was recorded, see [SetRecordCreation]. A nil
+// error has no attributes.
+func ExceptionAttributes(err error) []Attribute {
+	if err == nil {
+		return nil
+	}
+
+	typ, ok := Category(err)
+	if !ok {
+		typ = fmt.Sprintf("%T", err)
+	}
+
+	attrs := []Attribute{
+		{Key: ExceptionTypeKey, Value: typ},
+		{Key: ExceptionMessageKey, Value: err.Error()},
+	}
+
+	if st, ok := StackTrace(err); ok && !st.IsZero() {
+		attrs = append(attrs, Attribute{Key: ExceptionStacktraceKey, Value: goStack(st)})
+	}
+
+	if id, ok := GoroutineID(err); ok {
+		attrs = append(attrs, Attribute{Key: ThreadIDKey, Value: strconv.FormatUint(id, 10)})
+	}
+
+	return attrs
+}
+
+// SpanRecorder is implemented by a tracing span that errors can be recorded
+// on. An adapter for any tracer only needs to turn the attributes into that
+// tracer's representation of an event.
+type SpanRecorder interface {
+	AddEvent(name string, attrs ...Attribute)
+}
+
+// RecordException adds an "exception" event describing err to the span, as
+// described by [ExceptionAttributes]. Nothing is recorded if err is nil.
+func RecordException(span SpanRecorder, err error) {
+	if err == nil {
+		return
+	}
+
+	span.AddEvent("exception", ExceptionAttributes(err)...)
+}
+
+func goStack(st Stack) string {
+	return st.PanicStyle() + "\n"
+}
@@ -0,0 +1,82 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	std "errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type event struct {
+	name  string
+	attrs map[string]string
+}
+
+type fakeSpan struct {
+	events []event
+}
+
+func (s *fakeSpan) AddEvent(name string, attrs ...errors.Attribute) {
+	e := event{name: name, attrs: map[string]string{}}
+	for _, attr := range attrs {
+		e.attrs[attr.Key] = attr.Value
+	}
+	s.events = append(s.events, e)
+}
+
+func TestExceptionAttributes(t *testing.T) {
+	t.Run("categorized error", func(t *testing.T) {
+		line := nextLine()
+		err := errors.NewError[errors.TimeoutError]("timed out")
+
+		attrs := errors.ExceptionAttributes(err)
+		require.Len(t, attrs, 3)
+		assert.Equal(t, errors.Attribute{Key: "exception.type", Value: "TimeoutError"}, attrs[0])
+		assert.Equal(t, errors.Attribute{Key: "exception.message", Value: "timed out"}, attrs[1])
+		assert.Equal(t, "exception.stacktrace", attrs[2].Key)
+
+		lines := strings.Split(attrs[2].Value, "\n")
+		assert.Equal(t, "github.com/rclark/errors_test.TestExceptionAttributes.func1(...)", lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "\t"), "file path should be indented")
+		assert.Regexp(t, fmt.Sprintf(`/otel_test.go:%d \+0x[0-9a-f]+$`, line), lines[1], "file path should include line number and offset")
+		assert.Equal(t, "testing.tRunner(...)", lines[2])
+	})
+
+	t.Run("uncategorized error", func(t *testing.T) {
+		attrs := errors.ExceptionAttributes(errors.New("failure"))
+		require.Len(t, attrs, 3)
+		assert.Equal(t, "errors.Error", attrs[0].Value, "should use the Go type")
+	})
+
+	t.Run("without stack trace", func(t *testing.T) {
+		attrs := errors.ExceptionAttributes(std.New("failure"))
+		assert.Equal(t, []errors.Attribute{
+			{Key: "exception.type", Value: "*errors.errorString"},
+			{Key: "exception.message", Value: "failure"},
+		}, attrs)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		assert.Nil(t, errors.ExceptionAttributes(nil))
+	})
+}
+
+func TestRecordException(t *testing.T) {
+	span := &fakeSpan{}
+
+	errors.RecordException(span, nil)
+	assert.Empty(t, span.events, "should not record nil errors")
+
+	errors.RecordException(span, errors.NewError[errors.ConflictError]("conflict"))
+	require.Len(t, span.events, 1)
+	assert.Equal(t, "exception", span.events[0].name)
+	assert.Equal(t, "ConflictError", span.events[0].attrs["exception.type"])
+	assert.Equal(t, "conflict", span.events[0].attrs["exception.message"])
+	assert.Contains(t, span.events[0].attrs["exception.stacktrace"], "TestRecordException(...)")
+}
@@ -0,0 +1,80 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStackPanicStyle(t *testing.T) {
+	t.Run("matches the runtime", func(t *testing.T) {
+		st, _ := errors.StackTrace(errors.New("oops"))
+		runtimeTrace := string(debug.Stack())
+
+		trace := st.PanicStyle()
+		lines := strings.Split(trace, "\n")
+
+		require.Len(t, lines, 4, "runtime.goexit should be left out")
+		assert.Equal(t, "github.com/rclark/errors_test.TestStackPanicStyle.func1(...)", lines[0])
+		assert.Regexp(t, `^\t/.*/panic-stack_test\.go:\d+ \+0x[0-9a-f]+$`, lines[1])
+		assert.Equal(t, "testing.tRunner(...)", lines[2])
+		assert.Contains(t, runtimeTrace, "\n"+lines[3]+"\n", "the caller's location and offset should match the runtime's")
+	})
+
+	t.Run("can be parsed", func(t *testing.T) {
+		st, _ := errors.StackTrace(errors.New("oops"))
+
+		parsed, err := errors.ParseStack(st.PanicStyle())
+		require.NoError(t, err)
+		require.Len(t, parsed, 2)
+		assert.Equal(t, st[0].Function, parsed[0].Function)
+		assert.Equal(t, st[0].Line, parsed[0].Line)
+		assert.Zero(t, parsed[0].Offset())
+	})
+}
+
+func TestPanicStyle(t *testing.T) {
+	t.Run("captured error", func(t *testing.T) {
+		found := fmt.Sprintf("%v", errors.PanicStyle(errors.New("oops")))
+		assert.True(t, strings.HasPrefix(found, "panic: oops\n\ngoroutine 1 [running]:\ngithub.com/rclark/errors_test.TestPanicStyle.func1(...)\n\t"), found)
+	})
+
+	t.Run("spawned from", func(t *testing.T) {
+		err := <-errors.Go(func() error {
+			return errors.New("oops")
+		})
+
+		lines := strings.Split(fmt.Sprintf("%v", errors.PanicStyle(err)), "\n")
+		require.GreaterOrEqual(t, len(lines), 2)
+		assert.Equal(t, "created by github.com/rclark/errors_test.TestPanicStyle.func2", lines[len(lines)-2])
+		assert.Regexp(t, `^\t/.*/panic-stack_test\.go:\d+ \+0x[0-9a-f]+$`, lines[len(lines)-1])
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		assert.Equal(t, "<nil>", fmt.Sprintf("%v", errors.PanicStyle(nil)))
+	})
+
+	t.Run("spawned from a known goroutine", func(t *testing.T) {
+		errors.SetRecordCreation(true)
+		defer errors.SetRecordCreation(false)
+
+		parent, _ := errors.GoroutineID(errors.New("parent"))
+		err := <-errors.Go(func() error {
+			return errors.New("oops")
+		})
+
+		child, _ := errors.GoroutineID(err)
+		require.NotEqual(t, parent, child)
+
+		lines := strings.Split(fmt.Sprintf("%v", errors.PanicStyle(err)), "\n")
+		assert.Equal(t, fmt.Sprintf("goroutine %d [running]:", child), lines[2])
+		assert.Equal(t, fmt.Sprintf("created by github.com/rclark/errors_test.TestPanicStyle.func4 in goroutine %d", parent), lines[len(lines)-2])
+	})
+}
@@ -0,0 +1,122 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Offset returns the offset of the frame's program counter from the entry
+// point of its function, which the Go runtime shows as "+0x1d" in stack
+// traces. It is zero for frames that were not captured from the running
+// program, such as those created by [ParseStack].
+func (f Frame) Offset() uintptr {
+	if f.fn == nil || f.pc == 0 {
+		return 0
+	}
+
+	return f.pc - f.fn.Entry()
+}
+
+// PanicStyle renders the stack in the layout that the Go runtime uses when a
+// program panics, so that it can be read by tools built for panic output:
+//
+//	main.load(...)
+//		/path/to/main.go:12 +0x1d
+//	main.main(...)
+//		/path/to/main.go:30 +0x18
+//
+// The arguments of functions are not known, so they are always shown as
+// "(...)". Like the runtime, frames of unexported functions in the runtime
+// package, such as runtime.goexit, are left out.
+func (st Stack) PanicStyle() string {
+	lines := make([]string, 0, 2*len(st))
+	for _, f := range st {
+		if isRuntimeInternal(f) {
+			continue
+		}
+
+		lines = append(lines, f.Function+"(...)", "\t"+panicLocation(f))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// panicLocation renders the frame's file path, line number and offset as the
+// runtime does, as in "/path/to/main.go:12 +0x1d".
+func panicLocation(f Frame) string {
+	location := f.Path() + ":" + f.lineString()
+	if offset := f.Offset(); offset != 0 {
+		location += " +0x" + strconv.FormatUint(uint64(offset), 16)
+	}
+
+	return location
+}
+
+// isRuntimeInternal reports whether the frame's function is an unexported
+// function of the runtime package, which the runtime hides from stack traces.
+func isRuntimeInternal(f Frame) bool {
+	if !isRuntime(f) {
+		return false
+	}
+
+	name := f.FuncName()
+	return name == "" || !unicode.IsUpper(rune(name[0]))
+}
+
+// PanicStyle returns a formatter that renders err as the Go runtime would
+// render a panic with it, as in:
+//
+//	panic: <message>
+//
+//	goroutine 1 [running]:
+//	<package>.<function>(...)
+//		<filepath>:<line> +0x<offset>
+//	...
+//	created by <package>.<function> in goroutine <id>
+//		<filepath>:<line> +0x<offset>
+//
+// The goroutine is the one reported by [GoroutineID], or 1 if it was not
+// recorded. The trailing "created by" section is included when the error
+// reports where its goroutine was started via [SpawnedFrom]. It names the
+// goroutine that started it when [SetRecordCreation] was enabled at the time.
+// The verb is ignored.
+func PanicStyle(err error) fmt.Formatter {
+	return panicStyle{err: err}
+}
+
+type panicStyle struct {
+	err error
+}
+
+func (p panicStyle) Format(s fmt.State, _ rune) {
+	if p.err == nil {
+		_, _ = io.WriteString(s, "<nil>")
+		return
+	}
+
+	goroutine := uint64(1)
+	if c, ok := findCreation(p.err); ok {
+		goroutine = c.goroutine
+	}
+
+	b := strings.Builder{}
+	b.WriteString("panic: " + p.err.Error() + "\n\ngoroutine " + strconv.FormatUint(goroutine, 10) + " [running]:")
+
+	st, _ := StackTrace(p.err)
+	if trace := st.PanicStyle(); trace != "" {
+		b.WriteString("\n" + trace)
+	}
+
+	if e, ok := findOrigin(p.err); ok {
+		b.WriteString("\ncreated by " + e.origin[0].Function)
+		if e.spawner != 0 {
+			b.WriteString(" in goroutine " + strconv.FormatUint(e.spawner, 10))
+		}
+		b.WriteString("\n\t" + panicLocation(e.origin[0]))
+	}
+
+	_, _ = io.WriteString(s, b.String())
+}
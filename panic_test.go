@@ -0,0 +1,20 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/rclark/errors"
+)
+
+func ExamplePanicStyle() {
+	err, _ := errors.ParseError("oops\nmain.load\n\t/app/db.go:12\nmain.main\n\t/app/main.go:30")
+	fmt.Printf("%v\n", errors.PanicStyle(err))
+	// Output:
+	// panic: oops
+	//
+	// goroutine 1 [running]:
+	// main.load(...)
+	// 	/app/db.go:12
+	// main.main(...)
+	// 	/app/main.go:30
+}
@@ -0,0 +1,202 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseStack reconstructs a [Stack] from its textual representation. It
+// understands the layout produced by formatting a [Stack] or an [Error] with
+// %+v, as well as the goroutine stack traces printed by the Go runtime when a
+// program panics.
+//
+// Only the first stack trace in the text is parsed: parsing stops at a
+// "spawned from:" section, at a "caused by:" section that describes a wrapped
+// error, or at the start of another goroutine's stack trace.
+func ParseStack(s string) (Stack, error) {
+	p := parse(s)
+	if p.stack.IsZero() {
+		return nil, Errorf("no stack frames found")
+	}
+
+	return p.stack, p.err
+}
+
+// ParseError reconstructs an [Error] from its textual representation, as
+// described by [ParseStack]. The lines before the first stack frame become the
+// error message, and the frames after a "spawned from:" line become the stack
+// trace reported by [SpawnedFrom]. A "created at" line that follows the message,
+// as written when [SetRecordCreation] is enabled, becomes the time and
+// goroutine reported by [CreatedAt] and [GoroutineID]. The "caused by:"
+// sections that describe wrapped errors are ignored.
+func ParseError(s string) (Error, error) {
+	p := parse(s)
+	if p.stack.IsZero() && p.origin.IsZero() {
+		return Error{}, Errorf("no stack frames found")
+	}
+
+	message := strings.Join(p.message, "\n")
+	return Error{message: message, template: message, stack: p.stack, origin: p.origin, created: p.created}, p.err
+}
+
+type parsed struct {
+	message []string
+	created creation
+	stack   Stack
+	origin  Stack
+	err     error
+}
+
+func parse(s string) parsed {
+	var (
+		p        parsed
+		target   = &p.stack
+		lines    = strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+		routines = 0
+	)
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " ")
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			continue
+		case isGoroutineHeader(line):
+			routines++
+			if routines > 1 {
+				return p
+			}
+			continue
+		case line == "spawned from:":
+			if target == &p.origin {
+				return p
+			}
+			target = &p.origin
+			continue
+		case strings.HasPrefix(line, "caused by: ") && !p.stack.IsZero():
+			return p
+		case isCommonFrames(line):
+			continue
+		}
+
+		if p.stack.IsZero() && target == &p.stack {
+			if c, ok := parseCreation(line); ok {
+				p.created = c
+				continue
+			}
+		}
+
+		if i+1 < len(lines) && strings.HasPrefix(lines[i+1], "\t") && !strings.HasPrefix(line, "\t") {
+			f, err := parseFrame(line, lines[i+1])
+			if err == nil {
+				*target = append(*target, f)
+				i++
+				continue
+			}
+
+			if p.err == nil && len(p.stack) > 0 {
+				p.err = err
+			}
+		}
+
+		if p.stack.IsZero() && target == &p.stack {
+			p.message = append(p.message, line)
+		}
+	}
+
+	return p
+}
+
+// isGoroutineHeader reports whether the line starts a goroutine's stack trace
+// in the runtime's layout, as in "goroutine 1 [running]:".
+func isGoroutineHeader(line string) bool {
+	rest, ok := strings.CutPrefix(line, "goroutine ")
+	return ok && strings.HasSuffix(rest, "]:") && strings.Contains(rest, " [")
+}
+
+// parseCreation parses the line that follows the message of an error that
+// recorded its creation, as in "created at 2024-06-01T12:00:00Z in goroutine 7".
+func parseCreation(line string) (creation, bool) {
+	rest, ok := strings.CutPrefix(line, "created at ")
+	if !ok {
+		return creation{}, false
+	}
+
+	at, id, ok := strings.Cut(rest, " in goroutine ")
+	if !ok {
+		return creation{}, false
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return creation{}, false
+	}
+
+	goroutine, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || goroutine == 0 {
+		return creation{}, false
+	}
+
+	return creation{at: t, goroutine: goroutine}, true
+}
+
+// isCommonFrames reports whether the line notes the number of frames that a
+// wrapped error's stack trace shares with the stack trace that wraps it, as in
+// "(3 frames in common)".
+func isCommonFrames(line string) bool {
+	rest, ok := strings.CutPrefix(line, "(")
+	if !ok {
+		return false
+	}
+
+	n, rest, _ := strings.Cut(rest, " ")
+	if _, err := strconv.Atoi(n); err != nil {
+		return false
+	}
+
+	return rest == "frame in common)" || rest == "frames in common)"
+}
+
+// parseFrame parses a frame from a line naming its function, and a
+// tab-indented line with its file path and line number.
+func parseFrame(function, location string) (Frame, error) {
+	// The runtime describes the function that started a goroutine as in
+	// "created by main.main in goroutine 1".
+	if rest, ok := strings.CutPrefix(function, "created by "); ok {
+		function, _, _ = strings.Cut(rest, " in goroutine ")
+	}
+
+	// The runtime follows function names with their arguments, as in
+	// "main.(*T).f(0x1, {0x2, 0x3})".
+	if strings.HasSuffix(function, ")") {
+		if open := strings.LastIndex(function, "("); open > 0 && function[open-1] != '.' {
+			function = function[:open]
+		}
+	}
+
+	location = strings.TrimPrefix(location, "\t")
+
+	// The runtime follows line numbers with the offset of the program counter,
+	// as in "/path/to/main.go:12 +0x1d".
+	if space := strings.LastIndex(location, " +0x"); space >= 0 {
+		location = location[:space]
+	}
+
+	colon := strings.LastIndex(location, ":")
+	if colon < 0 {
+		return Frame{}, Errorf("invalid stack frame location %q", location)
+	}
+
+	line, err := strconv.Atoi(location[colon+1:])
+	if err != nil {
+		return Frame{}, Errorf("invalid line number in stack frame location %q", location)
+	}
+
+	return Frame{
+		Function: function,
+		File:     location[:colon],
+		Line:     line,
+		line:     location[colon+1:],
+	}, nil
+}
@@ -0,0 +1,180 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"context"
+	std "errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type location struct {
+	Function string
+	File     string
+	Line     int
+}
+
+func locations(st errors.Stack) []location {
+	found := make([]location, len(st))
+	for i, f := range st {
+		found[i] = location{f.Function, f.File, f.Line}
+	}
+
+	return found
+}
+
+const panicDump = `panic: something terrible [recovered]
+	panic: something terrible
+
+goroutine 7 [running]:
+main.(*worker).process(0xc000012345, {0x4b8e20, 0xc00001c030})
+	/home/runner/work/app/app/worker.go:42 +0x1d
+main.run.func1()
+	/home/runner/work/app/app/main.go:17 +0x25
+created by main.run in goroutine 1
+	/home/runner/work/app/app/main.go:15 +0x8b
+
+goroutine 1 [chan receive]:
+main.main()
+	/home/runner/work/app/app/main.go:30 +0x3c
+`
+
+func TestParseStack(t *testing.T) {
+	t.Run("formatted stack", func(t *testing.T) {
+		err := errors.New("failure")
+		stack, _ := errors.StackTrace(err)
+
+		found, parseErr := errors.ParseStack(fmt.Sprintf("%v", stack))
+		require.NoError(t, parseErr)
+		assert.Equal(t, locations(stack), locations(found), "should reconstruct the stack")
+	})
+
+	t.Run("formatted error", func(t *testing.T) {
+		err := errors.New("failure\nwith many lines")
+		stack, _ := errors.StackTrace(err)
+
+		found, parseErr := errors.ParseStack(fmt.Sprintf("%+v", err))
+		require.NoError(t, parseErr)
+		assert.Equal(t, locations(stack), locations(found), "should reconstruct the stack")
+		assert.Equal(t, fmt.Sprintf("%v", stack), fmt.Sprintf("%v", found), "should format the same way")
+	})
+
+	t.Run("runtime panic", func(t *testing.T) {
+		found, err := errors.ParseStack(panicDump)
+		require.NoError(t, err)
+		assert.Equal(t, []location{
+			{"main.(*worker).process", "/home/runner/work/app/app/worker.go", 42},
+			{"main.run.func1", "/home/runner/work/app/app/main.go", 17},
+			{"main.run", "/home/runner/work/app/app/main.go", 15},
+		}, locations(found), "should parse the first goroutine")
+	})
+
+	t.Run("runtime stack", func(t *testing.T) {
+		found, err := errors.ParseStack(string(debug.Stack()))
+		require.NoError(t, err)
+		assert.Equal(t, "runtime/debug.Stack", found[0].Function)
+		assert.Equal(t, "github.com/rclark/errors_test.TestParseStack.func4", found[1].Function)
+	})
+
+	t.Run("no frames", func(t *testing.T) {
+		_, err := errors.ParseStack("just a message")
+		assert.EqualError(t, err, "no stack frames found")
+	})
+
+	t.Run("invalid frame", func(t *testing.T) {
+		found, err := errors.ParseStack("main.main\n\t/main.go:10\nmain.f\n\t/main.go:twelve")
+		assert.EqualError(t, err, `invalid line number in stack frame location "/main.go:twelve"`)
+		assert.Len(t, found, 1, "should return the frames that could be parsed")
+	})
+}
+
+func TestParseError(t *testing.T) {
+	t.Run("formatted error", func(t *testing.T) {
+		original := errors.New("failure")
+
+		err, parseErr := errors.ParseError(fmt.Sprintf("%+v", original))
+		require.NoError(t, parseErr)
+		assert.Equal(t, "failure", err.Error())
+		assert.Equal(t, fmt.Sprintf("%+v", original), fmt.Sprintf("%+v", err), "should format the same way")
+	})
+
+	t.Run("spawn site", func(t *testing.T) {
+		original := <-errors.GoContext(context.Background(), func(context.Context) error {
+			return std.New("failure")
+		})
+
+		err, parseErr := errors.ParseError(fmt.Sprintf("%+v", original))
+		require.NoError(t, parseErr)
+		assert.Equal(t, "failure", err.Error())
+
+		expect, _ := errors.SpawnedFrom(original)
+		found, ok := errors.SpawnedFrom(err)
+		require.True(t, ok, "should have a spawn site")
+		assert.Equal(t, locations(expect), locations(found))
+	})
+
+	t.Run("wrapped causes", func(t *testing.T) {
+		inner := func() error {
+			return errors.New("inner")
+		}
+
+		original := errors.Errorf("outer: %w", inner(), errors.Overwrite())
+		formatted := fmt.Sprintf("%+v", original)
+		require.Contains(t, formatted, "\ncaused by: inner\n")
+
+		expect, _ := errors.StackTrace(original)
+		found, parseErr := errors.ParseStack(formatted)
+		require.NoError(t, parseErr)
+		assert.Equal(t, locations(expect), locations(found), "should only parse the outer stack trace")
+
+		spawned := <-errors.Go(func() error {
+			return errors.Errorf("outer: %w", inner(), errors.Overwrite())
+		})
+
+		err, parseErr := errors.ParseError(fmt.Sprintf("%+v", spawned))
+		require.NoError(t, parseErr)
+		assert.Equal(t, "outer: inner", err.Error())
+
+		expect, _ = errors.StackTrace(spawned)
+		assert.Equal(t, locations(expect), locations(err.StackTrace()), "should not include the stack trace of the cause")
+
+		expect, _ = errors.SpawnedFrom(spawned)
+		origin, _ := errors.SpawnedFrom(err)
+		assert.Equal(t, locations(expect), locations(origin), "should not include the stack trace of the cause")
+	})
+
+	t.Run("creation", func(t *testing.T) {
+		errors.SetRecordCreation(true)
+		original := errors.New("failure")
+		errors.SetRecordCreation(false)
+
+		err, parseErr := errors.ParseError(fmt.Sprintf("%+v", original))
+		require.NoError(t, parseErr)
+		assert.Equal(t, "failure", err.Error(), "should not include the creation in the message")
+		assert.Equal(t, fmt.Sprintf("%+v", original), fmt.Sprintf("%+v", err), "should format the same way")
+
+		expect, _ := errors.CreatedAt(original)
+		found, ok := errors.CreatedAt(err)
+		require.True(t, ok, "should have a creation time")
+		assert.True(t, expect.Equal(found))
+
+		expectID, _ := errors.GoroutineID(original)
+		foundID, _ := errors.GoroutineID(err)
+		assert.Equal(t, expectID, foundID)
+	})
+
+	t.Run("runtime panic", func(t *testing.T) {
+		err, parseErr := errors.ParseError(panicDump)
+		require.NoError(t, parseErr)
+		assert.Equal(t, "panic: something terrible [recovered]\n\tpanic: something terrible", err.Error())
+		assert.Len(t, err.StackTrace(), 3)
+		assert.True(t, strings.HasPrefix(fmt.Sprintf("%+s", err), "panic: something terrible [recovered]\n\tpanic: something terrible: [worker.go:42 main.go:17 main.go:15]"))
+	})
+}
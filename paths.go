@@ -0,0 +1,220 @@
+package errors
+
+import (
+	"path"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type trimOptions struct {
+	goroot   string
+	modcache string
+	root     string
+	module   string
+	main     string
+}
+
+// TrimOption configures the path rewriting performed by [TrimPaths].
+type TrimOption func(*trimOptions)
+
+// TrimGOROOT sets the GOROOT directory of the machine that built the binary.
+// By default, it is determined from the location of the runtime package.
+func TrimGOROOT(dir string) TrimOption {
+	return func(o *trimOptions) {
+		o.goroot = strings.TrimSuffix(dir, "/")
+	}
+}
+
+// TrimModCache sets the module cache directory of the machine that built the
+// binary. By default, any directory named "pkg/mod" is assumed to be the
+// module cache.
+func TrimModCache(dir string) TrimOption {
+	return func(o *trimOptions) {
+		o.modcache = strings.TrimSuffix(dir, "/")
+	}
+}
+
+// TrimModuleRoot sets the directory that the source code of the named module
+// was located in on the machine that built the binary. By default, the main
+// module is determined via [debug.ReadBuildInfo] and the directory it was
+// located in is inferred from the stack frames of its functions. If dir is
+// empty, it is inferred in the same way for the named module.
+func TrimModuleRoot(dir, module string) TrimOption {
+	return func(o *trimOptions) {
+		o.root = strings.TrimSuffix(dir, "/")
+		o.module = module
+	}
+}
+
+// TrimMainPackage sets the import path of package main of the binary, which
+// is used to infer the directory holding the main module from the stack frames
+// of functions in package main. By default, it is determined via
+// [debug.ReadBuildInfo].
+func TrimMainPackage(importPath string) TrimOption {
+	return func(o *trimOptions) {
+		o.main = importPath
+	}
+}
+
+// TrimPaths returns a function for [SetPathRewriter] that rewrites the
+// absolute file paths of frames into paths that start with the import path
+// of the package that contains them, such as "net/http/server.go" or
+// "github.com/rclark/errors/frame.go". This strips the directory layout of the
+// machine that built the binary: its GOROOT, its module cache, and the
+// directory holding the main module.
+func TrimPaths(opts ...TrimOption) func(Frame) string {
+	o := trimOptions{goroot: detectGOROOT(), module: mainModule(), main: mainPackage()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	t := &trimmer{o: o}
+	if o.root != "" {
+		t.root.Store(&o.root)
+	}
+
+	return t.rewrite
+}
+
+type trimmer struct {
+	o    trimOptions
+	root atomic.Pointer[string]
+}
+
+func (t *trimmer) rewrite(f Frame) string {
+	file := f.File
+
+	if t.o.goroot != "" && strings.HasPrefix(file, t.o.goroot+"/src/") {
+		return strings.TrimPrefix(file, t.o.goroot+"/src/")
+	}
+
+	// Frames from a binary built elsewhere may be from a different GOROOT.
+	if f.IsStdlib() {
+		if i := strings.LastIndex(file, "/src/"+f.Package()+"/"); i >= 0 {
+			return file[i+len("/src/"):]
+		}
+	}
+
+	if rel, ok := t.trimModCache(file); ok {
+		return rel
+	}
+
+	if root := t.moduleRoot(f); root != "" && strings.HasPrefix(file, root+"/") {
+		return t.o.module + strings.TrimPrefix(file, root)
+	}
+
+	return file
+}
+
+func (t *trimmer) trimModCache(file string) (string, bool) {
+	var rel string
+	if t.o.modcache != "" {
+		if !strings.HasPrefix(file, t.o.modcache+"/") {
+			return "", false
+		}
+
+		rel = strings.TrimPrefix(file, t.o.modcache+"/")
+	} else {
+		_, after, ok := strings.Cut(file, "/pkg/mod/")
+		if !ok {
+			return "", false
+		}
+
+		rel = after
+	}
+
+	// Drop the module version, as in "github.com/pkg/errors@v0.9.1/errors.go".
+	if at := strings.Index(rel, "@"); at >= 0 {
+		if slash := strings.Index(rel[at:], "/"); slash >= 0 {
+			rel = rel[:at] + rel[at+slash:]
+		}
+	}
+
+	return unescapeModulePath(rel), true
+}
+
+// moduleRoot returns the directory holding the main module. If it is not yet
+// known, it is inferred from the frame if the frame belongs to the main
+// module, including frames of package main.
+func (t *trimmer) moduleRoot(f Frame) string {
+	if root := t.root.Load(); root != nil {
+		return *root
+	}
+
+	if t.o.module == "" {
+		return ""
+	}
+
+	pkg := strings.TrimSuffix(f.Package(), "_test")
+	if pkg == "main" {
+		pkg = t.o.main
+	}
+
+	if pkg != t.o.module && !strings.HasPrefix(pkg, t.o.module+"/") {
+		return ""
+	}
+
+	rel := strings.TrimPrefix(pkg, t.o.module)
+	dir := path.Dir(f.File)
+	if !strings.HasSuffix(dir, rel) {
+		return ""
+	}
+
+	root := strings.TrimSuffix(dir, rel)
+	t.root.Store(&root)
+	return root
+}
+
+// unescapeModulePath reverses the escaping of upper-case letters that the
+// module cache applies to paths, as in "github.com/!burnt!sushi/toml".
+func unescapeModulePath(p string) string {
+	if !strings.Contains(p, "!") {
+		return p
+	}
+
+	b := strings.Builder{}
+	for i := 0; i < len(p); i++ {
+		if p[i] == '!' && i+1 < len(p) {
+			i++
+			b.WriteString(strings.ToUpper(p[i : i+1]))
+			continue
+		}
+
+		b.WriteByte(p[i])
+	}
+
+	return b.String()
+}
+
+var detectGOROOT = sync.OnceValue(func() string {
+	fn := runtime.FuncForPC(reflect.ValueOf(runtime.Gosched).Pointer())
+	if fn == nil {
+		return ""
+	}
+
+	file, _ := fn.FileLine(fn.Entry())
+	if i := strings.LastIndex(file, "/src/runtime/"); i >= 0 {
+		return file[:i]
+	}
+
+	return ""
+})
+
+var pathRewriter atomic.Pointer[func(Frame) string]
+
+// SetPathRewriter sets a function that determines the file path shown for each
+// [Frame] when it is formatted or serialized. [Frame.File] is unaffected.
+// Providing nil removes the rewriter, which is the default.
+//
+//	errors.SetPathRewriter(errors.TrimPaths())
+func SetPathRewriter(rewrite func(Frame) string) {
+	if rewrite == nil {
+		pathRewriter.Store(nil)
+		return
+	}
+
+	pathRewriter.Store(&rewrite)
+}
@@ -0,0 +1,146 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrimPaths(t *testing.T) {
+	t.Run("GOROOT", func(t *testing.T) {
+		trim := errors.TrimPaths(errors.TrimGOROOT("/usr/local/go"))
+		found := trim(errors.Frame{
+			Function: "net/http.(*conn).serve",
+			File:     "/usr/local/go/src/net/http/server.go",
+		})
+		assert.Equal(t, "net/http/server.go", found)
+	})
+
+	t.Run("other GOROOT", func(t *testing.T) {
+		trim := errors.TrimPaths()
+		found := trim(errors.Frame{
+			Function: "net/http.(*conn).serve",
+			File:     "/opt/hostedtoolcache/go/1.22.4/x64/src/net/http/server.go",
+		})
+		assert.Equal(t, "net/http/server.go", found)
+	})
+
+	t.Run("detected GOROOT", func(t *testing.T) {
+		err := errors.New("failure")
+		stack, _ := errors.StackTrace(err)
+
+		trim := errors.TrimPaths()
+		assert.Equal(t, "testing/testing.go", trim(stack[1]))
+	})
+
+	t.Run("module cache", func(t *testing.T) {
+		trim := errors.TrimPaths()
+		found := trim(errors.Frame{
+			Function: "github.com/BurntSushi/toml.Decode",
+			File:     "/home/runner/go/pkg/mod/github.com/!burnt!sushi/toml@v1.2.0/decode.go",
+		})
+		assert.Equal(t, "github.com/BurntSushi/toml/decode.go", found)
+	})
+
+	t.Run("explicit module cache", func(t *testing.T) {
+		trim := errors.TrimPaths(errors.TrimModCache("/cache"))
+		found := trim(errors.Frame{
+			Function: "github.com/pkg/errors.New",
+			File:     "/cache/github.com/pkg/errors@v0.9.1/errors.go",
+		})
+		assert.Equal(t, "github.com/pkg/errors/errors.go", found)
+
+		found = trim(errors.Frame{
+			Function: "github.com/pkg/errors.New",
+			File:     "/home/runner/go/pkg/mod/github.com/pkg/errors@v0.9.1/errors.go",
+		})
+		assert.Equal(t, "/home/runner/go/pkg/mod/github.com/pkg/errors@v0.9.1/errors.go", found, "should only trim the provided module cache")
+	})
+
+	t.Run("module root", func(t *testing.T) {
+		trim := errors.TrimPaths(errors.TrimModuleRoot("/home/runner/work/repo/repo/", "github.com/org/repo"))
+		found := trim(errors.Frame{
+			Function: "main.main",
+			File:     "/home/runner/work/repo/repo/cmd/server/main.go",
+		})
+		assert.Equal(t, "github.com/org/repo/cmd/server/main.go", found)
+	})
+
+	t.Run("inferred module root", func(t *testing.T) {
+		trim := errors.TrimPaths(errors.TrimModuleRoot("", "github.com/org/repo"))
+		found := trim(errors.Frame{
+			Function: "github.com/org/repo/internal/db.Query",
+			File:     "/home/runner/work/repo/repo/internal/db/query.go",
+		})
+		assert.Equal(t, "github.com/org/repo/internal/db/query.go", found)
+
+		found = trim(errors.Frame{Function: "main.main", File: "/home/runner/work/repo/repo/main.go"})
+		assert.Equal(t, "github.com/org/repo/main.go", found, "should reuse the inferred module root")
+	})
+
+	t.Run("detected module root", func(t *testing.T) {
+		err := errors.New("failure")
+		stack, _ := errors.StackTrace(err)
+		root := strings.TrimSuffix(stack[0].File, "/paths_test.go")
+
+		trim := errors.TrimPaths()
+		assert.Equal(t, "github.com/rclark/errors/paths_test.go", trim(stack[0]))
+
+		found := trim(errors.Frame{Function: "main.main", File: root + "/cmd/tool/main.go"})
+		assert.Equal(t, "github.com/rclark/errors/cmd/tool/main.go", found, "should reuse the detected module root")
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		trim := errors.TrimPaths()
+		found := trim(errors.Frame{Function: "main.main", File: "/somewhere/else/main.go"})
+		assert.Equal(t, "/somewhere/else/main.go", found, "should leave unknown paths alone")
+	})
+
+	t.Run("inferred from package main", func(t *testing.T) {
+		trim := errors.TrimPaths(errors.TrimModuleRoot("", "example.com/exp"), errors.TrimMainPackage("example.com/exp"))
+		found := trim(errors.Frame{Function: "main.main", File: "/tmp/exp/main.go"})
+		assert.Equal(t, "example.com/exp/main.go", found)
+
+		trim = errors.TrimPaths(errors.TrimModuleRoot("", "example.com/exp"), errors.TrimMainPackage("example.com/exp/cmd/tool"))
+		found = trim(errors.Frame{Function: "main.run", File: "/tmp/exp/cmd/tool/run.go"})
+		assert.Equal(t, "example.com/exp/cmd/tool/run.go", found)
+
+		found = trim(errors.Frame{Function: "example.com/exp/internal/db.Query", File: "/tmp/exp/internal/db/query.go"})
+		assert.Equal(t, "example.com/exp/internal/db/query.go", found, "should reuse the inferred module root")
+	})
+}
+
+func TestSetPathRewriter(t *testing.T) {
+	errors.SetPathRewriter(errors.TrimPaths())
+	defer errors.SetPathRewriter(nil)
+
+	line := nextLine()
+	err := errors.New("failure")
+
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+	assert.Equal(t, fmt.Sprintf("\tgithub.com/rclark/errors/paths_test.go:%d", line), lines[2], "should rewrite formatted paths")
+	assert.Equal(t, "\ttesting/testing.go", strings.Split(lines[4], ":")[0], "should rewrite formatted paths")
+
+	stack, _ := errors.StackTrace(err)
+	assert.True(t, strings.HasPrefix(stack[0].File, "/"), "should not change the frame")
+	assert.Equal(t, "github.com/rclark/errors/paths_test.go", stack[0].Path())
+
+	b, jsonErr := json.Marshal(stack[0])
+	require.NoError(t, jsonErr)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"file": "github.com/rclark/errors/paths_test.go",
+		"line": %d,
+		"function": "github.com/rclark/errors_test.TestSetPathRewriter"
+	}`, line), string(b), "should rewrite serialized paths")
+
+	event := errors.NewSentryEvent(err)
+	frames := event.Exception.Values[0].Stacktrace.Frames
+	assert.Equal(t, "github.com/rclark/errors/paths_test.go", frames[len(frames)-1].AbsPath, "should rewrite paths sent to Sentry")
+}
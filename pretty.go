@@ -0,0 +1,121 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rclark/errors/internal/ansi"
+)
+
+type prettyOptions struct {
+	color bool
+}
+
+// PrettyOption configures the rendering of [Pretty] and [FprintPretty].
+type PrettyOption func(*prettyOptions)
+
+// Color turns colors on or off. Without it, [Pretty] uses no colors, and
+// [FprintPretty] uses them when they are supported.
+func Color(enabled bool) PrettyOption {
+	return func(o *prettyOptions) {
+		o.color = enabled
+	}
+}
+
+// Pretty returns a formatter that renders err for reading in a terminal: the
+// message and [Category] are followed by the error's [Stack], with in-app
+// frames highlighted and library frames dimmed. The verb is ignored.
+//
+// Since the destination of fmt functions is unknown to a formatter, colors are
+// only used when the [Color] option is provided. Use [FprintPretty] to detect
+// whether the destination supports them instead. Formatting an [Error] with
+// %#v is the same as formatting it with Pretty.
+//
+//	fmt.Printf("%v\n", errors.Pretty(err, errors.Color(true)))
+func Pretty(err error, opts ...PrettyOption) fmt.Formatter {
+	o := prettyOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return pretty{err: err, o: o}
+}
+
+// FprintPretty writes err to w as rendered by [Pretty]. Colors are used when w
+// is a terminal and the NO_COLOR environment variable is not set.
+func FprintPretty(w io.Writer, err error, opts ...PrettyOption) error {
+	o := prettyOptions{color: useColor(w)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	_, writeErr := fmt.Fprintln(w, pretty{err: err, o: o})
+	return writeErr
+}
+
+// useColor reports whether colors should be written to w.
+func useColor(w io.Writer) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	f, ok := w.(interface{ Stat() (os.FileInfo, error) })
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+type pretty struct {
+	err error
+	o   prettyOptions
+}
+
+func (p pretty) paint(code, s string) string {
+	if !p.o.color {
+		return s
+	}
+
+	return code + s + ansi.Reset
+}
+
+func (p pretty) Format(s fmt.State, _ rune) {
+	if p.err == nil {
+		_, _ = io.WriteString(s, "<nil>")
+		return
+	}
+
+	b := strings.Builder{}
+	b.WriteString(p.paint(ansi.Bold+ansi.Red, p.err.Error()))
+
+	if category, ok := Category(p.err); ok {
+		b.WriteString(" " + p.paint(ansi.Magenta, "["+category+"]"))
+	}
+
+	st, _ := StackTrace(p.err)
+	p.writeStack(&b, st)
+
+	if origin, ok := SpawnedFrom(p.err); ok {
+		b.WriteString("\n" + p.paint(ansi.Bold, "spawned from:"))
+		p.writeStack(&b, origin)
+	}
+
+	_, _ = io.WriteString(s, b.String())
+}
+
+func (p pretty) writeStack(b *strings.Builder, st Stack) {
+	module := mainModule()
+	for _, f := range st.filtered() {
+		location := p.paint(ansi.Cyan, f.Path()) + ":" + p.paint(ansi.Yellow, f.lineString())
+		if inModule(f, module) {
+			b.WriteString("\n  " + p.paint(ansi.Bold, f.Function) + "\n      " + location)
+			continue
+		}
+
+		b.WriteString("\n  " + p.paint(ansi.Dim, f.Function) + "\n      " + location)
+	}
+}
@@ -0,0 +1,69 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPretty(t *testing.T) {
+	t.Run("without colors", func(t *testing.T) {
+		line := nextLine()
+		err := errors.NewError[errors.MissingError]("not found")
+
+		found := fmt.Sprintf("%v", errors.Pretty(err, errors.Color(false)))
+		lines := strings.Split(found, "\n")
+
+		require.GreaterOrEqual(t, len(lines), 3)
+		assert.Equal(t, "not found [MissingError]", lines[0])
+		assert.Equal(t, "  github.com/rclark/errors_test.TestPretty.func1", lines[1])
+		assert.True(t, strings.HasSuffix(lines[2], fmt.Sprintf("/pretty_test.go:%d", line)), lines[2])
+		assert.True(t, strings.HasPrefix(lines[2], "      /"), lines[2])
+	})
+
+	t.Run("with colors", func(t *testing.T) {
+		err := errors.New("oops")
+
+		found := fmt.Sprintf("%v", errors.Pretty(err, errors.Color(true)))
+
+		assert.True(t, strings.HasPrefix(found, "\x1b[1m\x1b[31moops\x1b[0m\n"), found)
+		assert.Contains(t, found, "\n  \x1b[1mgithub.com/rclark/errors_test.TestPretty.func2\x1b[0m\n", "in-app frames are bold")
+		assert.Contains(t, found, "\n  \x1b[2mtesting.tRunner\x1b[0m\n", "library frames are dim")
+		assert.Contains(t, found, "pretty_test.go\x1b[0m:\x1b[33m")
+	})
+
+	t.Run("spawned from", func(t *testing.T) {
+		err := <-errors.Go(func() error {
+			return errors.New("oops")
+		})
+
+		found := fmt.Sprintf("%v", errors.Pretty(err, errors.Color(false)))
+		assert.Contains(t, found, "\nspawned from:\n  github.com/rclark/errors_test.TestPretty.func3\n")
+	})
+
+	t.Run("error verb", func(t *testing.T) {
+		err := errors.New("oops")
+		assert.Equal(t, fmt.Sprintf("%v", errors.Pretty(err)), fmt.Sprintf("%#v", err))
+		assert.NotContains(t, fmt.Sprintf("%#v", err), "\x1b[", "colors are not used unless requested")
+	})
+
+	t.Run("writer", func(t *testing.T) {
+		err := errors.New("oops")
+
+		var b bytes.Buffer
+		require.NoError(t, errors.FprintPretty(&b, err))
+		assert.Equal(t, fmt.Sprintf("%v\n", errors.Pretty(err, errors.Color(false))), b.String(), "colors are not used when writing to a buffer")
+
+		b.Reset()
+		t.Setenv("NO_COLOR", "1")
+		require.NoError(t, errors.FprintPretty(&b, err, errors.Color(true)))
+		assert.Contains(t, b.String(), "\x1b[", "forced colors override NO_COLOR")
+	})
+}
@@ -0,0 +1,30 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReport(t *testing.T) {
+	err := errors.New("underlying")
+	err = errors.NewError[errors.ConflictError]("conflict", errors.FromError(err))
+
+	report := errors.NewReport(err)
+	assert.Equal(t, err, report.Err, "should retain the error")
+	assert.Equal(t, "underlying", report.Message, "should have the error message")
+	assert.Equal(t, "conflict", report.UserMessage, "should have the user-facing message")
+	assert.Equal(t, "ConflictError", report.Category, "should have the category")
+	assert.Equal(t, errors.Fingerprint(err), report.Fingerprint, "should have the fingerprint")
+	assert.Equal(t, "github.com/rclark/errors_test.TestNewReport", report.Stack[0].Function, "should have the stack trace")
+	assert.False(t, report.Time.IsZero(), "should have the time")
+	assert.Empty(t, report.SpawnedFrom, "should not have a spawn site")
+
+	report = errors.NewReport(<-errors.Go(func() error { return err }))
+	require.NotEmpty(t, report.SpawnedFrom, "should have a spawn site")
+	assert.Equal(t, "github.com/rclark/errors_test.TestNewReport", report.SpawnedFrom[0].Function)
+}
@@ -0,0 +1,172 @@
+package errors
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Report is the information that a [Reporter] extracts from an error and
+// delivers to each of its [Sink]s.
+type Report struct {
+	Err         error      `json:"-"`
+	Message     string     `json:"message"`
+	UserMessage string     `json:"user_message,omitempty"`
+	Category    string     `json:"category,omitempty"`
+	Fingerprint string     `json:"fingerprint"`
+	Stack       Stack      `json:"stack,omitempty"`
+	SpawnedFrom Stack      `json:"spawned_from,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	Goroutine   uint64     `json:"goroutine,omitempty"`
+	Time        time.Time  `json:"time"`
+}
+
+// NewReport extracts a [Report] from the provided error.
+func NewReport(err error) Report {
+	r := Report{
+		Err:         err,
+		Message:     err.Error(),
+		Fingerprint: Fingerprint(err),
+		Time:        time.Now(),
+	}
+
+	r.UserMessage, _ = UserFacingMessage(err)
+	r.Category, _ = Category(err)
+	r.Stack, _ = StackTrace(err)
+	r.SpawnedFrom, _ = SpawnedFrom(err)
+
+	if c, ok := findCreation(err); ok {
+		r.CreatedAt = &c.at
+		r.Goroutine = c.goroutine
+	}
+
+	return r
+}
+
+// Sink is a destination for the [Report]s produced by a [Reporter].
+type Sink interface {
+	Send(ctx context.Context, r Report) error
+}
+
+type reporterOptions struct {
+	sinks  []Sink
+	limit  int
+	per    time.Duration
+	dedupe time.Duration
+}
+
+// ReporterOption configures a [Reporter].
+type ReporterOption func(*reporterOptions)
+
+// ToSinks adds sinks that a [Reporter] will deliver each [Report] to.
+func ToSinks(sinks ...Sink) ReporterOption {
+	return func(o *reporterOptions) {
+		o.sinks = append(o.sinks, sinks...)
+	}
+}
+
+// RateLimit limits a [Reporter] to delivering at most n reports in each period
+// of the provided duration. Reports beyond the limit are dropped. If n or per is
+// not positive, reports are not limited.
+func RateLimit(n int, per time.Duration) ReporterOption {
+	return func(o *reporterOptions) {
+		o.limit = n
+		o.per = per
+	}
+}
+
+// Dedupe prevents a [Reporter] from delivering more than one report with the
+// same [Fingerprint] within the provided duration. Duplicates are dropped.
+func Dedupe(window time.Duration) ReporterOption {
+	return func(o *reporterOptions) {
+		o.dedupe = window
+	}
+}
+
+// Reporter delivers errors to a set of [Sink]s. It is safe for concurrent use.
+type Reporter struct {
+	o reporterOptions
+
+	mu     sync.Mutex
+	window time.Time
+	count  int
+	seen   map[string]time.Time
+}
+
+// NewReporter creates a new [Reporter].
+func NewReporter(opts ...ReporterOption) *Reporter {
+	r := &Reporter{seen: map[string]time.Time{}}
+	for _, opt := range opts {
+		opt(&r.o)
+	}
+
+	return r
+}
+
+// Report delivers the error to each of the reporter's sinks, unless it is
+// dropped by rate limiting or deduplication. Errors returned by the sinks are
+// joined together and returned.
+func (r *Reporter) Report(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	report := NewReport(err)
+	if !r.allow(report) {
+		return nil
+	}
+
+	recordReported(report)
+
+	errs := make([]error, 0, len(r.o.sinks))
+	for _, sink := range r.o.sinks {
+		errs = append(errs, sink.Send(ctx, report))
+	}
+
+	return Join(errs...)
+}
+
+func (r *Reporter) allow(report Report) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.o.dedupe > 0 {
+		if last, ok := r.seen[report.Fingerprint]; ok && report.Time.Sub(last) < r.o.dedupe {
+			return false
+		}
+
+		r.prune(report.Time)
+	}
+
+	if r.o.limit > 0 && r.o.per > 0 {
+		if report.Time.Sub(r.window) >= r.o.per {
+			r.window = report.Time
+			r.count = 0
+		}
+
+		if r.count >= r.o.limit {
+			return false
+		}
+
+		r.count++
+	}
+
+	if r.o.dedupe > 0 {
+		r.seen[report.Fingerprint] = report.Time
+	}
+
+	return true
+}
+
+// prune forgets fingerprints that have fallen outside of the dedupe window.
+func (r *Reporter) prune(now time.Time) {
+	if len(r.seen) < 1024 {
+		return
+	}
+
+	for fingerprint, last := range r.seen {
+		if now.Sub(last) >= r.o.dedupe {
+			delete(r.seen, fingerprint)
+		}
+	}
+}
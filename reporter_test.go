@@ -0,0 +1,119 @@
+package errors_test
+
+import (
+	"context"
+	std "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingSink struct{}
+
+func (failingSink) Send(context.Context, errors.Report) error {
+	return std.New("sink failed")
+}
+
+func TestReporter(t *testing.T) {
+	t.Run("fans out to sinks", func(t *testing.T) {
+		a, b := &errors.MemorySink{}, &errors.MemorySink{}
+		r := errors.NewReporter(errors.ToSinks(a, b))
+
+		err := r.Report(context.Background(), errors.New("failure"))
+		require.NoError(t, err, "should report successfully")
+		assert.Len(t, a.Reports(), 1, "first sink should receive the report")
+		assert.Len(t, b.Reports(), 1, "second sink should receive the report")
+	})
+
+	t.Run("nil error", func(t *testing.T) {
+		sink := &errors.MemorySink{}
+		r := errors.NewReporter(errors.ToSinks(sink))
+
+		require.NoError(t, r.Report(context.Background(), nil))
+		assert.Empty(t, sink.Reports(), "should not report nil errors")
+	})
+
+	t.Run("sink errors", func(t *testing.T) {
+		sink := &errors.MemorySink{}
+		r := errors.NewReporter(errors.ToSinks(failingSink{}, sink))
+
+		err := r.Report(context.Background(), errors.New("failure"))
+		assert.EqualError(t, err, "sink failed", "should return sink errors")
+		assert.Len(t, sink.Reports(), 1, "other sinks should still receive the report")
+	})
+
+	t.Run("dedupe", func(t *testing.T) {
+		sink := &errors.MemorySink{}
+		r := errors.NewReporter(errors.ToSinks(sink), errors.Dedupe(time.Hour))
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, r.Report(context.Background(), failedLookup(i)))
+		}
+		require.NoError(t, r.Report(context.Background(), errors.New("different")))
+
+		reports := sink.Reports()
+		require.Len(t, reports, 2, "should drop duplicates")
+		assert.Equal(t, "failed to find record 0", reports[0].Message)
+		assert.Equal(t, "different", reports[1].Message)
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		sink := &errors.MemorySink{}
+		r := errors.NewReporter(errors.ToSinks(sink), errors.RateLimit(2, time.Hour))
+
+		for i := 0; i < 5; i++ {
+			require.NoError(t, r.Report(context.Background(), errors.New("failure")))
+		}
+
+		assert.Len(t, sink.Reports(), 2, "should drop reports beyond the limit")
+	})
+
+	t.Run("rate limit window", func(t *testing.T) {
+		sink := &errors.MemorySink{}
+		r := errors.NewReporter(errors.ToSinks(sink), errors.RateLimit(1, time.Millisecond))
+
+		require.NoError(t, r.Report(context.Background(), errors.New("first")))
+		time.Sleep(2 * time.Millisecond)
+		require.NoError(t, r.Report(context.Background(), errors.New("second")))
+
+		assert.Len(t, sink.Reports(), 2, "should allow reports in a new window")
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		sink := &errors.MemorySink{}
+		r := errors.NewReporter(errors.ToSinks(sink), errors.RateLimit(50, time.Hour), errors.Dedupe(time.Hour))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = r.Report(context.Background(), errors.New("failure"))
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, sink.Reports(), 1, "should deliver exactly one report")
+	})
+
+	t.Run("rate limit disabled", func(t *testing.T) {
+		for _, opt := range []errors.ReporterOption{
+			errors.RateLimit(0, time.Hour),
+			errors.RateLimit(1, 0),
+			errors.RateLimit(1, -time.Hour),
+		} {
+			sink := &errors.MemorySink{}
+			r := errors.NewReporter(errors.ToSinks(sink), opt)
+
+			for i := 0; i < 5; i++ {
+				require.NoError(t, r.Report(context.Background(), errors.New("failure")))
+			}
+
+			assert.Len(t, sink.Reports(), 5, "should not limit reports")
+		}
+	})
+}
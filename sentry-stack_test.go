@@ -0,0 +1,88 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	std "errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSentryEvent(t *testing.T) {
+	t.Run("exception chain", func(t *testing.T) {
+		err := errors.NewError[errors.MissingError]("not found", errors.FromError(std.New("no rows")))
+		err = errors.Errorf("lookup failed: %w", err, errors.Overwrite())
+
+		event := errors.NewSentryEvent(err)
+		assert.Len(t, event.EventID, 32, "should have an event id")
+		assert.Equal(t, "go", event.Platform)
+		assert.Equal(t, "error", event.Level)
+		assert.Equal(t, map[string]string{"category": "MissingError"}, event.Tags, "should tag the category")
+		assert.Equal(t, map[string]any{"user_message": "not found"}, event.Extra, "should include the user-facing message")
+		assert.Equal(t, []string{errors.Fingerprint(err)}, event.Fingerprint, "should group by fingerprint")
+
+		values := event.Exception.Values
+		require.Len(t, values, 5, "should include each error in the chain")
+
+		assert.Equal(t, "*errors.errorString", values[0].Type, "innermost error should be first")
+		assert.Equal(t, "no rows", values[0].Value)
+		assert.Nil(t, values[0].Stacktrace, "should not have a stack trace")
+
+		assert.Equal(t, "errors.Error", values[1].Type)
+		assert.Nil(t, values[1].Stacktrace, "should not repeat the stack trace of the wrapping error")
+
+		assert.Equal(t, "MissingError", values[2].Type, "should use the category as the type")
+		assert.Equal(t, "no rows", values[2].Value)
+		require.NotNil(t, values[2].Stacktrace, "should have a stack trace")
+
+		assert.Equal(t, "*fmt.wrapError", values[3].Type)
+		assert.Nil(t, values[3].Stacktrace, "should not have a stack trace")
+
+		assert.Equal(t, "errors.Error", values[4].Type, "outermost error should be last")
+		assert.Equal(t, "lookup failed: no rows", values[4].Value)
+		require.NotNil(t, values[4].Stacktrace, "should have its own stack trace")
+	})
+
+	t.Run("stack trace", func(t *testing.T) {
+		line := nextLine()
+		err := errors.New("failure")
+
+		event := errors.NewSentryEvent(err)
+		require.Len(t, event.Exception.Values, 1)
+
+		frames := event.Exception.Values[0].Stacktrace.Frames
+		require.GreaterOrEqual(t, len(frames), 2)
+
+		last := frames[len(frames)-1]
+		assert.Equal(t, "TestNewSentryEvent.func2", last.Function, "most recent frame should be last")
+		assert.Equal(t, "github.com/rclark/errors_test", last.Module)
+		assert.Equal(t, "sentry-stack_test.go", last.Filename)
+		assert.True(t, strings.HasSuffix(last.AbsPath, "/sentry-stack_test.go"))
+		assert.Equal(t, line, last.Lineno)
+		assert.True(t, last.InApp, "test frame should be in-app")
+
+		runner := frames[len(frames)-2]
+		assert.Equal(t, "tRunner", runner.Function)
+		assert.Equal(t, "testing", runner.Module)
+		assert.False(t, runner.InApp, "standard library frame should not be in-app")
+	})
+	t.Run("adapted stack traces", func(t *testing.T) {
+		for name, err := range map[string]error{
+			"pkg/errors": newPkgError("no rows"),
+			"go-errors":  newGoError("no rows"),
+		} {
+			event := errors.NewSentryEvent(fmt.Errorf("lookup failed: %w", err))
+			require.Len(t, event.Exception.Values, 2, name)
+			require.NotNil(t, event.Exception.Values[0].Stacktrace, name)
+
+			frames := event.Exception.Values[0].Stacktrace.Frames
+			assert.Equal(t, "TestNewSentryEvent.func3", frames[len(frames)-1].Function, name)
+			assert.Nil(t, event.Exception.Values[1].Stacktrace, name)
+		}
+	})
+}
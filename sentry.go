@@ -0,0 +1,216 @@
+package errors
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// SentryEvent is an event payload that can be sent to a Sentry-compatible
+// ingest endpoint.
+type SentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   time.Time         `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Level       string            `json:"level"`
+	Exception   SentryExceptions  `json:"exception"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Extra       map[string]any    `json:"extra,omitempty"`
+	Fingerprint []string          `json:"fingerprint,omitempty"`
+}
+
+// SentryExceptions is the list of exceptions in a [SentryEvent], ordered from
+// the innermost wrapped error to the outermost.
+type SentryExceptions struct {
+	Values []SentryException `json:"values"`
+}
+
+// SentryException describes one error in the Unwrap chain of a [SentryEvent].
+type SentryException struct {
+	Type       string            `json:"type"`
+	Value      string            `json:"value"`
+	Stacktrace *SentryStacktrace `json:"stacktrace,omitempty"`
+}
+
+// SentryStacktrace is the stack trace of a [SentryException]. Its frames are
+// ordered from the oldest call to the most recent.
+type SentryStacktrace struct {
+	Frames []SentryFrame `json:"frames"`
+}
+
+// SentryFrame is a single frame of a [SentryStacktrace].
+type SentryFrame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	Filename string `json:"filename"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+// NewSentryEvent encodes err as a [SentryEvent].
+//
+// Each error in the Unwrap chain becomes a [SentryException]. An exception is
+// given a stack trace when the error provides a [Stack] that differs from that
+// of the error that wraps it. Frames that belong to the main module are marked
+// as in-app, and the error's [Category] and [Fingerprint] are used for tagging
+// and grouping. The time and goroutine that the error was created in are
+// included as extra data if they were recorded, see [SetRecordCreation].
+func NewSentryEvent(err error) SentryEvent {
+	event := SentryEvent{
+		EventID:     newEventID(),
+		Timestamp:   time.Now(),
+		Platform:    "go",
+		Level:       "error",
+		Fingerprint: []string{Fingerprint(err)},
+	}
+
+	if category, ok := Category(err); ok {
+		event.Tags = map[string]string{"category": category}
+	}
+
+	if msg, ok := UserFacingMessage(err); ok {
+		event.Extra = map[string]any{"user_message": msg}
+	}
+
+	if c, ok := findCreation(err); ok {
+		if event.Extra == nil {
+			event.Extra = map[string]any{}
+		}
+		event.Extra["created_at"] = c.at
+		event.Extra["goroutine"] = c.goroutine
+	}
+
+	var (
+		values []SentryException
+		last   Stack
+	)
+
+	for e := err; e != nil; {
+		exception := SentryException{Type: errorType(e), Value: e.Error()}
+
+		if stack, ok := ownStack(e); ok && !stack.IsZero() && !sameStack(stack, last) {
+			exception.Stacktrace = newSentryStacktrace(stack)
+			last = stack
+		}
+
+		values = append(values, exception)
+
+		next := UnwrapAny(e)
+		if len(next) == 0 {
+			break
+		}
+		e = next[0]
+	}
+
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+	event.Exception.Values = values
+
+	return event
+}
+
+func newSentryStacktrace(st Stack) *SentryStacktrace {
+	module := mainModule()
+
+	frames := make([]SentryFrame, len(st))
+	for i, f := range st {
+		frames[len(st)-1-i] = SentryFrame{
+			Function: f.FuncName(),
+			Module:   f.Package(),
+			Filename: f.ShortFile(),
+			AbsPath:  f.Path(),
+			Lineno:   f.Line,
+			InApp:    inModule(f, module),
+		}
+	}
+
+	return &SentryStacktrace{Frames: frames}
+}
+
+// errorType names the type of an error, preferring its [Category].
+func errorType(err error) string {
+	if c, ok := err.(categorized); ok {
+		return c.category()
+	}
+
+	return fmt.Sprintf("%T", err)
+}
+
+func sameStack(a, b Stack) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newEventID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+type sentrySink struct {
+	endpoint string
+	header   http.Header
+	client   *http.Client
+}
+
+// NewSentrySink creates a [Sink] that sends each [Report] as a [SentryEvent]
+// to the project identified by the provided DSN, which takes the form
+// "https://<key>@<host>/<project>". If client is nil, [http.DefaultClient] is
+// used.
+func NewSentrySink(dsn string, client *http.Client) (Sink, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, Errorf("invalid sentry dsn: %w", err)
+	}
+
+	key := u.User.Username()
+	dir, project := path.Split(u.Path)
+	if key == "" || project == "" {
+		return nil, Errorf("invalid sentry dsn: missing key or project")
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	endpoint := url.URL{
+		Scheme: u.Scheme,
+		Host:   u.Host,
+		Path:   strings.TrimSuffix(dir, "/") + "/api/" + project + "/store/",
+	}
+
+	header := http.Header{}
+	header.Set("X-Sentry-Auth", "Sentry sentry_version=7, sentry_client=rclark-errors/1.0, sentry_key="+key)
+
+	return sentrySink{endpoint: endpoint.String(), header: header, client: client}, nil
+}
+
+func (s sentrySink) Send(ctx context.Context, r Report) error {
+	event := NewSentryEvent(r.Err)
+	event.Timestamp = r.Time
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return WithStack(err)
+	}
+
+	return post(ctx, s.client, s.endpoint, body, s.header)
+}
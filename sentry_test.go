@@ -0,0 +1,51 @@
+package errors_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSentrySink(t *testing.T) {
+	t.Run("sends events", func(t *testing.T) {
+		var (
+			path  string
+			auth  string
+			event errors.SentryEvent
+		)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			auth = r.Header.Get("X-Sentry-Auth")
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &event)
+		}))
+		defer server.Close()
+
+		dsn := strings.Replace(server.URL, "://", "://public@", 1) + "/42"
+		sink, err := errors.NewSentrySink(dsn, server.Client())
+		require.NoError(t, err)
+
+		r := errors.NewReporter(errors.ToSinks(sink))
+		require.NoError(t, r.Report(context.Background(), errors.NewError[errors.ConflictError]("conflict")))
+
+		assert.Equal(t, "/api/42/store/", path, "should post to the store endpoint")
+		assert.Contains(t, auth, "sentry_key=public", "should authenticate with the dsn key")
+		require.Len(t, event.Exception.Values, 2)
+		assert.Equal(t, "ConflictError", event.Exception.Values[1].Type)
+		assert.Equal(t, "conflict", event.Exception.Values[1].Value)
+	})
+
+	t.Run("invalid dsn", func(t *testing.T) {
+		_, err := errors.NewSentrySink("https://sentry.example.com/42", nil)
+		assert.ErrorContains(t, err, "invalid sentry dsn")
+	})
+}
@@ -0,0 +1,140 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type writerSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink creates a [Sink] that writes a human-readable rendering of each
+// [Report] to the provided writer.
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{w: w}
+}
+
+func (s *writerSink) Send(_ context.Context, r Report) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	label := r.Fingerprint
+	if r.Category != "" {
+		label = r.Category + " " + label
+	}
+
+	_, err := fmt.Fprintf(s.w, "%s [%s] %s%v\n", r.Time.Format(time.RFC3339), label, r.Message, r.Stack)
+	return err
+}
+
+type slogSink struct {
+	logger *slog.Logger
+}
+
+// NewSlogSink creates a [Sink] that logs each [Report] to the provided logger
+// at the error level.
+func NewSlogSink(logger *slog.Logger) Sink {
+	return slogSink{logger: logger}
+}
+
+func (s slogSink) Send(ctx context.Context, r Report) error {
+	attrs := []slog.Attr{slog.String("fingerprint", r.Fingerprint)}
+	if r.Category != "" {
+		attrs = append(attrs, slog.String("category", r.Category))
+	}
+	if r.UserMessage != "" {
+		attrs = append(attrs, slog.String("user_message", r.UserMessage))
+	}
+	if !r.Stack.IsZero() {
+		attrs = append(attrs, slog.Any("stack", r.Stack))
+	}
+
+	s.logger.LogAttrs(ctx, slog.LevelError, r.Message, attrs...)
+	return nil
+}
+
+// MemorySink is a [Sink] that retains each [Report] in memory. It is intended
+// for use in tests, and is safe for concurrent use.
+type MemorySink struct {
+	mu      sync.Mutex
+	reports []Report
+}
+
+// Send retains the report.
+func (s *MemorySink) Send(_ context.Context, r Report) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reports = append(s.reports, r)
+	return nil
+}
+
+// Reports returns the reports that have been retained, in the order that they
+// were sent.
+func (s *MemorySink) Reports() []Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Report(nil), s.reports...)
+}
+
+type httpSink struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewHTTPSink creates a [Sink] that posts each [Report] as JSON to the provided
+// endpoint. If client is nil, [http.DefaultClient] is used.
+func NewHTTPSink(endpoint string, client *http.Client) Sink {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return httpSink{endpoint: endpoint, client: client}
+}
+
+func (s httpSink) Send(ctx context.Context, r Report) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return WithStack(err)
+	}
+
+	return post(ctx, s.client, s.endpoint, body, nil)
+}
+
+// post sends a JSON body to the endpoint, and returns an error if the response
+// does not indicate success.
+func post(ctx context.Context, client *http.Client, endpoint string, body []byte, header http.Header) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return WithStack(err)
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return WithStack(err)
+	}
+	defer res.Body.Close()
+
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return Errorf("unexpected response from %s: %s", endpoint, res.Status)
+	}
+
+	return nil
+}
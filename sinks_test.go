@@ -0,0 +1,101 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterSink(t *testing.T) {
+	buf := bytes.Buffer{}
+	r := errors.NewReporter(errors.ToSinks(errors.NewWriterSink(&buf)))
+
+	err := errors.NewError[errors.MissingError]("missing")
+	require.NoError(t, r.Report(context.Background(), err))
+
+	lines := strings.Split(buf.String(), "\n")
+	assert.Contains(t, lines[0], "[MissingError "+errors.Fingerprint(err)+"] missing", "first line should describe the error")
+	assert.Equal(t, "github.com/rclark/errors_test.TestWriterSink", lines[1], "second line should be the test function")
+	assert.Contains(t, lines[2], "sinks_test.go:", "third line should be the file path")
+}
+
+func TestSlogSink(t *testing.T) {
+	buf := bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	r := errors.NewReporter(errors.ToSinks(errors.NewSlogSink(logger)))
+
+	err := errors.NewError[errors.MissingError]("not found", errors.FromError(errors.New("no rows")))
+	require.NoError(t, r.Report(context.Background(), err))
+
+	var found struct {
+		Level       string         `json:"level"`
+		Msg         string         `json:"msg"`
+		Category    string         `json:"category"`
+		UserMessage string         `json:"user_message"`
+		Fingerprint string         `json:"fingerprint"`
+		Stack       []errors.Frame `json:"stack"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &found))
+
+	assert.Equal(t, "ERROR", found.Level)
+	assert.Equal(t, "no rows", found.Msg)
+	assert.Equal(t, "MissingError", found.Category)
+	assert.Equal(t, "not found", found.UserMessage)
+	assert.Equal(t, errors.Fingerprint(err), found.Fingerprint)
+	require.NotEmpty(t, found.Stack, "should log the stack trace")
+	assert.Equal(t, "github.com/rclark/errors_test.TestSlogSink", found.Stack[0].Function)
+}
+
+func TestHTTPSink(t *testing.T) {
+	t.Run("posts reports", func(t *testing.T) {
+		var (
+			body        []byte
+			contentType string
+		)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentType = r.Header.Get("Content-Type")
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		r := errors.NewReporter(errors.ToSinks(errors.NewHTTPSink(server.URL, server.Client())))
+		err := errors.NewError[errors.BadInputError]("bad input")
+		require.NoError(t, r.Report(context.Background(), err))
+
+		assert.Equal(t, "application/json", contentType)
+
+		var found errors.Report
+		require.NoError(t, json.Unmarshal(body, &found))
+		assert.Equal(t, "bad input", found.Message)
+		assert.Equal(t, "bad input", found.UserMessage)
+		assert.Equal(t, "BadInputError", found.Category)
+		assert.Equal(t, errors.Fingerprint(err), found.Fingerprint)
+		assert.Equal(t, "github.com/rclark/errors_test.TestHTTPSink.func1", found.Stack[0].Function)
+		assert.Contains(t, found.Stack[0].File, "sinks_test.go")
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		r := errors.NewReporter(errors.ToSinks(errors.NewHTTPSink(server.URL, nil)))
+		err := r.Report(context.Background(), errors.New("failure"))
+		assert.ErrorContains(t, err, "500 Internal Server Error")
+	})
+}
@@ -0,0 +1,16 @@
+//go:build !errors_source && !errors_nocapture
+
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceContextDisabled(t *testing.T) {
+	err := errors.New("oops")
+	assert.Equal(t, fmt.Sprintf("%+v", err), fmt.Sprintf("%+v", errors.SourceContext(err, 2)), "should not read source files")
+}
@@ -0,0 +1,7 @@
+//go:build errors_source
+
+package errors
+
+// sourceEnabled reports whether [SourceContext] reads source files. It is
+// enabled by the errors_source build tag.
+const sourceEnabled = true
@@ -0,0 +1,151 @@
+package errors
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SourceContext returns a formatter for err that, when formatted with %+v,
+// shows the n lines of source code before and after the line of each frame
+// that belongs to the main module. The source code is read from [Frame.File]
+// when the error is formatted, so it is only available on the machine that
+// built the binary. Frames whose files cannot be read are shown without source
+// code. Other verbs format err as usual.
+//
+//	fmt.Printf("%+v", errors.SourceContext(err, 2))
+//
+// Reading source code is compiled out by default, so frames are never shown
+// with source code. Build with the errors_source build tag, for example in
+// development, to enable it.
+func SourceContext(err error, n int) fmt.Formatter {
+	return sourceContext{err: err, n: n}
+}
+
+type sourceContext struct {
+	err error
+	n   int
+}
+
+func (c sourceContext) Format(s fmt.State, verb rune) {
+	if verb != 'v' || !s.Flag('+') || c.err == nil {
+		_, _ = fmt.Fprintf(s, fmt.FormatString(s, verb), c.err)
+		return
+	}
+
+	_, _ = io.WriteString(s, c.err.Error())
+
+	st, _ := StackTrace(c.err)
+	c.writeStack(s, st)
+
+	if origin, ok := SpawnedFrom(c.err); ok {
+		_, _ = io.WriteString(s, "\nspawned from:")
+		c.writeStack(s, origin)
+	}
+}
+
+func (c sourceContext) writeStack(w io.Writer, st Stack) {
+	module := mainModule()
+	for _, f := range st.filtered() {
+		_, _ = io.WriteString(w, "\n"+f.long())
+		if inModule(f, module) {
+			_, _ = io.WriteString(w, sourceLines(f, c.n))
+		}
+	}
+}
+
+// sourceLines renders the lines of source code surrounding the frame's line,
+// as in:
+//
+//	    11 | 	if err != nil {
+//	>   12 | 		return errors.New("oops")
+//	    13 | 	}
+//
+// Each line is preceded by a newline and indented with a tab.
+func sourceLines(f Frame, n int) string {
+	lines := sources.lines(f.File)
+	if n < 0 || f.Line < 1 || f.Line > len(lines) {
+		return ""
+	}
+
+	first := max(f.Line-n, 1)
+	last := min(f.Line+n, len(lines))
+	width := len(strconv.Itoa(last))
+
+	b := strings.Builder{}
+	for i := first; i <= last; i++ {
+		marker := " "
+		if i == f.Line {
+			marker = ">"
+		}
+
+		fmt.Fprintf(&b, "\n\t%s %*d | %s", marker, width, i, strings.TrimRight(lines[i-1], " \t\r"))
+	}
+
+	return b.String()
+}
+
+// sources caches the lines of the most recently read source files.
+var sources = newFileCache(64)
+
+// fileCache is a concurrency-safe LRU cache of the lines of source files. Files
+// that cannot be read are cached as having no lines.
+type fileCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	files    map[string]*list.Element
+}
+
+type cachedFile struct {
+	path  string
+	lines []string
+}
+
+func newFileCache(capacity int) *fileCache {
+	return &fileCache{
+		capacity: capacity,
+		order:    list.New(),
+		files:    map[string]*list.Element{},
+	}
+}
+
+func (c *fileCache) lines(path string) []string {
+	if !sourceEnabled || path == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	if el, ok := c.files[path]; ok {
+		c.order.MoveToFront(el)
+		c.mu.Unlock()
+		return el.Value.(*cachedFile).lines
+	}
+	c.mu.Unlock()
+
+	var lines []string
+	if b, err := os.ReadFile(path); err == nil {
+		lines = strings.Split(string(b), "\n")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.files[path]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*cachedFile).lines
+	}
+
+	c.files[path] = c.order.PushFront(&cachedFile{path: path, lines: lines})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.files, oldest.Value.(*cachedFile).path)
+	}
+
+	return lines
+}
@@ -0,0 +1,45 @@
+//go:build errors_source && !errors_nocapture
+
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceContext(t *testing.T) {
+	t.Run("in-app frames", func(t *testing.T) {
+		line := nextLine()
+		err := errors.New("oops")
+
+		found := fmt.Sprintf("%+v", errors.SourceContext(err, 1))
+		lines := strings.Split(found, "\n")
+
+		require.GreaterOrEqual(t, len(lines), 6)
+		assert.Equal(t, "oops", lines[0])
+		assert.Equal(t, "github.com/rclark/errors_test.TestSourceContext.func1", lines[1])
+		assert.Equal(t, fmt.Sprintf("\t  %d | \t\tline := nextLine()", line-1), lines[3])
+		assert.Equal(t, fmt.Sprintf("\t> %d | \t\terr := errors.New(\"oops\")", line), lines[4])
+		assert.Equal(t, fmt.Sprintf("\t  %d | ", line+1), lines[5])
+		assert.Equal(t, "testing.tRunner", lines[6], "frames outside of the module have no source code")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		err, parseErr := errors.ParseError("oops\nmain.main\n\t/does/not/exist.go:12")
+		require.NoError(t, parseErr)
+
+		found := fmt.Sprintf("%+v", errors.SourceContext(err, 2))
+		assert.Equal(t, "oops\nmain.main\n\t/does/not/exist.go:12", found)
+	})
+
+	t.Run("other verbs", func(t *testing.T) {
+		err := errors.New("oops")
+		assert.Equal(t, "oops", fmt.Sprintf("%v", errors.SourceContext(err, 2)))
+		assert.Equal(t, fmt.Sprintf("%+s", err), fmt.Sprintf("%+s", errors.SourceContext(err, 2)))
+	})
+}
@@ -0,0 +1,95 @@
+//go:build !errors_nocapture
+
+package errors_test
+
+import (
+	std "errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleStackTracer() {
+	err := std.New("no stack trace")
+
+	var hasStackTrace errors.StackTracer
+	if errors.As(err, &hasStackTrace) {
+		log.Fatal("error should not have a stack trace")
+	}
+
+	err = errors.New("with stack trace")
+	if errors.As(err, &hasStackTrace) {
+		fmt.Println(hasStackTrace.StackTrace()[0].Function)
+	}
+	// Output: github.com/rclark/errors_test.ExampleStackTracer
+}
+
+func TestStackFilters(t *testing.T) {
+	err := errors.New("failure")
+	stack, ok := errors.StackTrace(err)
+	require.True(t, ok, "should have a stack trace")
+	require.Equal(t, []string{
+		"github.com/rclark/errors_test.TestStackFilters",
+		"testing.tRunner",
+		"runtime.goexit",
+	}, functions(stack))
+
+	t.Run("Filter", func(t *testing.T) {
+		filtered := stack.Filter(func(f errors.Frame) bool {
+			return f.Function != "testing.tRunner"
+		})
+		assert.Equal(t, []string{
+			"github.com/rclark/errors_test.TestStackFilters",
+			"runtime.goexit",
+		}, functions(filtered))
+	})
+
+	t.Run("TrimRuntime", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"github.com/rclark/errors_test.TestStackFilters",
+			"testing.tRunner",
+		}, functions(stack.TrimRuntime()))
+	})
+
+	t.Run("OnlyModule", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"github.com/rclark/errors_test.TestStackFilters",
+		}, functions(stack.OnlyModule("github.com/rclark/errors")))
+		assert.Empty(t, stack.OnlyModule("github.com/other"))
+	})
+
+	t.Run("DropStdlib", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"github.com/rclark/errors_test.TestStackFilters",
+		}, functions(stack.DropStdlib()))
+	})
+
+	t.Run("leaves the stack intact", func(t *testing.T) {
+		_ = stack.TrimRuntime().DropStdlib()
+		assert.Len(t, stack, 3)
+	})
+}
+
+func TestSetDefaultFilter(t *testing.T) {
+	errors.SetDefaultFilter(func(st errors.Stack) errors.Stack {
+		return st.TrimRuntime().DropStdlib()
+	})
+	defer errors.SetDefaultFilter(nil)
+
+	line := nextLine()
+	err := errors.New("failure")
+
+	found := fmt.Sprintf("%+s", err)
+	assert.Equal(t, fmt.Sprintf("failure: [stack-trace-stack_test.go:%d]", line), found, "should filter formatted stack")
+
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+	assert.Len(t, lines, 3, "should filter formatted stack")
+
+	stack, _ := errors.StackTrace(err)
+	assert.Len(t, stack, 3, "should not filter the raw stack")
+}
@@ -3,16 +3,23 @@ package errors
 import (
 	"fmt"
 	"io"
+	"strings"
+	"sync/atomic"
 )
 
-// Stack represents a stack trace.
+// Stack represents a stack trace. Errors created at the same call site share
+// their stack traces internally, but [StackTrace], [SpawnedFrom] and
+// [Error.StackTrace] return copies, so the caller may modify them.
 type Stack []Frame
 
 // Format formats the stack of Frames according to the fmt.Formatter interface.
+// The filter set by [SetDefaultFilter], if any, is applied to the stack before
+// it is formatted.
 //
 //   - %s	[<filename>:<line> ...]
 //   - %v	<package>.<function>\n\t<filepath>:<line>\n\t...
 func (st Stack) Format(s fmt.State, verb rune) {
+	st = st.filtered()
 	if st.IsZero() {
 		return
 	}
@@ -20,8 +27,7 @@ func (st Stack) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		for _, f := range st {
-			_, _ = io.WriteString(s, "\n")
-			f.Format(s, verb)
+			_, _ = io.WriteString(s, "\n"+f.long())
 		}
 	case 's':
 		_, _ = io.WriteString(s, "[")
@@ -29,17 +35,117 @@ func (st Stack) Format(s fmt.State, verb rune) {
 			if i > 0 {
 				_, _ = io.WriteString(s, " ")
 			}
-			f.Format(s, verb)
+			_, _ = io.WriteString(s, f.short())
 		}
 		_, _ = io.WriteString(s, "]")
 	}
 }
 
+// filtered applies the filter set by [SetDefaultFilter], if any.
+func (st Stack) filtered() Stack {
+	if filter := defaultFilter.Load(); filter != nil {
+		return (*filter)(st)
+	}
+
+	return st
+}
+
 // IsZero reports whether the stack trace is empty.
 func (st Stack) IsZero() bool {
 	return len(st) == 0
 }
 
+// CommonSuffix returns the number of frames at the end of the stack, starting
+// from the outermost caller, that are the same as those at the end of the
+// other stack. Frames are compared by their function, file and line. The
+// stacks of an error and of the error it wraps usually share most of their
+// callers.
+func (st Stack) CommonSuffix(other Stack) int {
+	n := 0
+	for n < len(st) && n < len(other) {
+		a, b := st[len(st)-1-n], other[len(other)-1-n]
+		if a.Function != b.Function || a.File != b.File || a.Line != b.Line {
+			break
+		}
+
+		n++
+	}
+
+	return n
+}
+
+// Diff returns a new [Stack] with the frames that are not part of the
+// [Stack.CommonSuffix] of the stack and the other stack.
+func (st Stack) Diff(other Stack) Stack {
+	return append(Stack(nil), st[:len(st)-st.CommonSuffix(other)]...)
+}
+
+// Filter returns a new [Stack] containing only the frames for which keep
+// returns true.
+func (st Stack) Filter(keep func(Frame) bool) Stack {
+	filtered := make(Stack, 0, len(st))
+	for _, f := range st {
+		if keep(f) {
+			filtered = append(filtered, f)
+		}
+	}
+
+	return filtered
+}
+
+// TrimRuntime returns a new [Stack] without the frames from the runtime
+// package at its start and end, such as runtime.goexit and runtime.main.
+func (st Stack) TrimRuntime() Stack {
+	start, end := 0, len(st)
+	for start < end && isRuntime(st[start]) {
+		start++
+	}
+	for end > start && isRuntime(st[end-1]) {
+		end--
+	}
+
+	return append(Stack(nil), st[start:end]...)
+}
+
+// OnlyModule returns a new [Stack] containing only the frames of functions
+// declared in packages whose import path starts with prefix.
+func (st Stack) OnlyModule(prefix string) Stack {
+	return st.Filter(func(f Frame) bool {
+		return strings.HasPrefix(f.Package(), prefix)
+	})
+}
+
+// DropStdlib returns a new [Stack] without the frames of functions declared in
+// the standard library.
+func (st Stack) DropStdlib() Stack {
+	return st.Filter(func(f Frame) bool {
+		return !f.IsStdlib()
+	})
+}
+
+func isRuntime(f Frame) bool {
+	return f.Package() == "runtime"
+}
+
+var defaultFilter atomic.Pointer[func(Stack) Stack]
+
+// SetDefaultFilter sets a filter that is applied to every [Stack] when it is
+// formatted, for example to hide frames from the runtime and the standard
+// library. The stack returned by [StackTrace] is unaffected. Providing nil
+// removes the filter, which is the default.
+//
+//	errors.SetDefaultFilter(func(st errors.Stack) errors.Stack {
+//		return st.TrimRuntime().DropStdlib()
+//	})
+func SetDefaultFilter(filter func(Stack) Stack) {
+	if filter == nil {
+		defaultFilter.Store(nil)
+		return
+	}
+
+	defaultFilter.Store(&filter)
+}
+
 // StackTracer is implemented by [Error]. It can be used in external contexts
 // to check whether an error has a stack trace that this package can expose.
 type StackTracer interface {
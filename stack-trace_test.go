@@ -1,24 +1,38 @@
 package errors_test
 
 import (
-	std "errors"
-	"fmt"
-	"log"
+	"testing"
 
 	"github.com/rclark/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func ExampleStackTracer() {
-	err := std.New("no stack trace")
-
-	var hasStackTrace errors.StackTracer
-	if errors.As(err, &hasStackTrace) {
-		log.Fatal("error should not have a stack trace")
+func functions(st errors.Stack) []string {
+	names := make([]string, len(st))
+	for i, f := range st {
+		names[i] = f.Function
 	}
 
-	err = errors.New("with stack trace")
-	if errors.As(err, &hasStackTrace) {
-		fmt.Println(hasStackTrace.StackTrace()[0].Function)
-	}
-	// Output: github.com/rclark/errors_test.ExampleStackTracer
+	return names
+}
+
+func TestStackCommonSuffix(t *testing.T) {
+	inner, err := errors.ParseStack("main.query\n\t/app/db.go:12\nmain.load\n\t/app/db.go:30\nmain.main\n\t/app/main.go:8")
+	require.NoError(t, err)
+
+	outer, err := errors.ParseStack("main.handle\n\t/app/api.go:20\nmain.load\n\t/app/db.go:30\nmain.main\n\t/app/main.go:8")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, inner.CommonSuffix(outer))
+	assert.Equal(t, 2, outer.CommonSuffix(inner))
+	assert.Equal(t, []string{"main.query"}, functions(inner.Diff(outer)))
+	assert.Equal(t, 3, inner.CommonSuffix(inner))
+	assert.True(t, inner.Diff(inner).IsZero())
+	assert.Equal(t, 0, inner.CommonSuffix(nil))
+	assert.Equal(t, functions(inner), functions(inner.Diff(nil)))
+
+	moved, err := errors.ParseStack("main.load\n\t/app/db.go:31\nmain.main\n\t/app/main.go:8")
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.CommonSuffix(moved), "frames on different lines differ")
 }
@@ -18,31 +18,48 @@ type UserFacingError struct {
 	msg string
 }
 
-// UserFacingOption configures the creation of a [UserFacingError].
-type UserFacingOption func(*options)
+// UserFacingOption configures the creation of a [UserFacingError]. A
+// [CaptureOption], such as [SkipFrames], is also a [StackOption]. See
+// [StackOption] for how both changed from function types to interfaces.
+type UserFacingOption interface {
+	applyUserFacing(*options)
+}
+
+type userFacingOption func(*options)
+
+func (f userFacingOption) applyUserFacing(o *options) { f(o) }
 
 // FromError sets the [UserFacingError] to wrap the provided error.
 func FromError(err error) UserFacingOption {
-	return func(o *options) {
+	return userFacingOption(func(o *options) {
 		o.underlying = err
-	}
+	})
 }
 
 // OverwriteStackTrace sets the stack trace of a [UserFacingError] to the place that
 // [NewUserFacingError] was called, overwriting any stack trace that may have
 // been included in an underlying error provided via [FromError].
 func OverwriteStackTrace() UserFacingOption {
-	return func(o *options) {
+	return userFacingOption(func(o *options) {
 		o.overwrite = true
-	}
+	})
 }
 
 // Skip sets the number of stack frames to skip when creating a
-// [UserFacingError].
+// [UserFacingError], counted from runtime.Callers. Prefer [SkipFrames], which
+// counts from the caller of [NewUserFacingError], or [Helper].
 func Skip(i int) UserFacingOption {
-	return func(o *options) {
+	return userFacingOption(func(o *options) {
 		o.skip = i
-	}
+	})
+}
+
+// inCategory records the category of the [ErrorType] that a [UserFacingError]
+// is being created for.
+func inCategory(category string) UserFacingOption {
+	return userFacingOption(func(o *options) {
+		o.category = category
+	})
 }
 
 // NewUserFacingError creates a new [UserFacingError]. The provided message is
@@ -50,30 +67,26 @@ func Skip(i int) UserFacingOption {
 // via [FromError], the provided message will also be used as the underlying
 // error message.
 func NewUserFacingError(msg string, opts ...UserFacingOption) error {
-	uf := UserFacingError{msg: msg}
-
-	o := options{overwrite: false, skip: 3}
+	o := options{}
 	for _, opt := range opts {
-		opt(&o)
+		opt.applyUserFacing(&o)
 	}
 
-	if o.underlying == nil {
-		uf.err = newError(msg, o.skip)
-		return uf
-	}
+	uf := UserFacingError{msg: msg}
 
 	var te tracedError
-	if !As(o.underlying, &te) {
-		uf.err = wrapError(o.underlying, o.skip)
-		return uf
-	}
-
-	if !o.overwrite {
+	switch {
+	case o.underlying == nil:
+		uf.err = newError(msg, o)
+	case !As(o.underlying, &te):
+		uf.err = wrapError(o.underlying, o)
+	case !o.overwrite:
 		uf.err = te
-		return uf
+	default:
+		uf.err = wrapError(o.underlying, o)
 	}
 
-	uf.err = wrapError(o.underlying, o.skip)
+	recordCreated(uf, o.category)
 	return uf
 }
 
@@ -124,7 +137,8 @@ type ErrorType interface {
 // NewError creates a new error of the provided generic type with the given
 // message intended for a user external to the system.
 func NewError[T ErrorType](msg string, opts ...UserFacingOption) error {
-	opts = append([]UserFacingOption{Skip(4)}, opts...)
+	var t T
+	opts = append([]UserFacingOption{inCategory(any(t).(categorized).category())}, opts...)
 	uf := NewUserFacingError(msg, opts...).(UserFacingError)
 	return error(T{UserFacingError: uf})
 }
@@ -134,12 +148,30 @@ func asType[T ErrorType](err error) (T, bool) {
 	return e, As(err, &e)
 }
 
+type categorized interface {
+	error
+	category() string
+}
+
+// Category returns the name of the first [ErrorType] found in err's tree, such
+// as "BadInputError". If none was found, the returned bool will be false.
+func Category(err error) (string, bool) {
+	var c categorized
+	if As(err, &c) {
+		return c.category(), true
+	}
+
+	return "", false
+}
+
 // BadInputError is an [ErrorType] that represents a situation where some input was
 // invalid.
 type BadInputError struct {
 	UserFacingError
 }
 
+func (BadInputError) category() string { return "BadInputError" }
+
 // IsBadInput reports whether the provided error is a [BadInputError] and
 // returns it if so.
 func IsBadInput(err error) (BadInputError, bool) {
@@ -152,6 +184,8 @@ type NotAllowedError struct {
 	UserFacingError
 }
 
+func (NotAllowedError) category() string { return "NotAllowedError" }
+
 // IsNotAllowed reports whether the provided error is a [NotAllowedError] and
 // returns it if so.
 func IsNotAllowed(err error) (NotAllowedError, bool) {
@@ -164,6 +198,8 @@ type MissingError struct {
 	UserFacingError
 }
 
+func (MissingError) category() string { return "MissingError" }
+
 // IsMissing reports whether the provided error is a [MissingError] and returns
 // it if so.
 func IsMissing(err error) (MissingError, bool) {
@@ -176,6 +212,8 @@ type ConflictError struct {
 	UserFacingError
 }
 
+func (ConflictError) category() string { return "ConflictError" }
+
 // IsConflict reports whether the provided error is a [ConflictError] and
 // returns it if so.
 func IsConflict(err error) (ConflictError, bool) {
@@ -188,6 +226,8 @@ type TimeoutError struct {
 	UserFacingError
 }
 
+func (TimeoutError) category() string { return "TimeoutError" }
+
 // IsTimeout reports whether the provided error is a [TimeoutError] and returns
 // it if so.
 func IsTimeout(err error) (TimeoutError, bool) {
@@ -200,6 +240,8 @@ type UnexpectedError struct {
 	UserFacingError
 }
 
+func (UnexpectedError) category() string { return "UnexpectedError" }
+
 // IsUnexpected reports whether the provided error is an [UnexpectedError] and
 // returns it if so.
 func IsUnexpected(err error) (UnexpectedError, bool) {